@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/cache"
+	"github.com/devesh-kumar/aws-resources-cost-board/models"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultCachePolicy applies to collectors without their own policy
+var defaultCachePolicy = cache.Policy{TTL: 5 * time.Minute, StaleTTL: 15 * time.Minute, Timeout: 2 * time.Minute}
+
+// cachePolicies holds the cache policy of each collector. Cost Explorer data only changes a few
+// times a day and every request is billed, so it is kept much longer than inventory.
+var cachePolicies = map[string]cache.Policy{
+	"ec2":             {TTL: 2 * time.Minute, StaleTTL: 10 * time.Minute, Timeout: time.Minute},
+	"rds":             {TTL: 5 * time.Minute, StaleTTL: 15 * time.Minute, Timeout: time.Minute},
+	"ebs":             {TTL: 5 * time.Minute, StaleTTL: 15 * time.Minute, Timeout: time.Minute},
+	"public-ips":      {TTL: 5 * time.Minute, StaleTTL: 15 * time.Minute, Timeout: time.Minute},
+	"log-groups":      {TTL: 15 * time.Minute, StaleTTL: time.Hour, Timeout: 5 * time.Minute},
+	"snapshots":       {TTL: 30 * time.Minute, StaleTTL: 2 * time.Hour, Timeout: 5 * time.Minute},
+	"stopped-costs":   {TTL: 5 * time.Minute, StaleTTL: 15 * time.Minute, Timeout: 2 * time.Minute},
+	"snapshot-report": {TTL: 30 * time.Minute, StaleTTL: 2 * time.Hour, Timeout: 5 * time.Minute},
+	"cost":            {TTL: 6 * time.Hour, StaleTTL: 18 * time.Hour, Timeout: 2 * time.Minute},
+	"data-transfer":   {TTL: 6 * time.Hour, StaleTTL: 18 * time.Hour, Timeout: 2 * time.Minute},
+	"account":         {TTL: 24 * time.Hour, StaleTTL: 24 * time.Hour, Timeout: 30 * time.Second},
+}
+
+// cached returns a collector's value from the shared cache, calling fetch when it is missing or
+// expired. ?fresh=true bypasses cached values.
+func cached[T any](s *Server, c *gin.Context, collector, key string, fetch func(ctx context.Context) (T, error)) (T, error) {
+	policy, ok := cachePolicies[collector]
+	if !ok {
+		policy = defaultCachePolicy
+	}
+	fresh, _ := strconv.ParseBool(c.Query("fresh"))
+	return cache.Get(c.Request.Context(), s.cache, collector+":"+key, policy, fresh, fetch)
+}
+
+// ec2Instances returns EC2 instances in the given states
+func (s *Server) ec2Instances(c *gin.Context, states []string) ([]models.EC2Instance, error) {
+	return cached(s, c, "ec2", strings.Join(states, ","), func(ctx context.Context) ([]models.EC2Instance, error) {
+		return s.aws.GetEC2Instances(ctx, states...)
+	})
+}
+
+// rdsInstances returns RDS instances in the given states
+func (s *Server) rdsInstances(c *gin.Context, states []string) ([]models.RDSInstance, error) {
+	return cached(s, c, "rds", strings.Join(states, ","), func(ctx context.Context) ([]models.RDSInstance, error) {
+		return s.aws.GetRDSInstances(ctx, states...)
+	})
+}
+
+// ebsVolumes returns all EBS volumes
+func (s *Server) ebsVolumes(c *gin.Context) ([]models.EBSVolume, error) {
+	return cached(s, c, "ebs", "", s.aws.GetEBSVolumes)
+}
+
+// logGroups returns all CloudWatch Log Groups
+func (s *Server) logGroups(c *gin.Context) ([]models.CloudWatchLogGroup, error) {
+	return cached(s, c, "log-groups", "", s.aws.GetCloudWatchLogGroups)
+}
+
+// publicIPv4Addresses returns all billed public IPv4 addresses
+func (s *Server) publicIPv4Addresses(c *gin.Context) ([]models.PublicIPv4Address, error) {
+	return cached(s, c, "public-ips", "", s.aws.GetPublicIPv4Addresses)
+}
+
+// ebsSnapshots returns all EBS snapshots owned by the account
+func (s *Server) ebsSnapshots(c *gin.Context) ([]models.EBSSnapshot, error) {
+	return cached(s, c, "snapshots", "ebs", s.aws.GetEBSSnapshots)
+}
+
+// rdsSnapshots returns all RDS snapshots
+func (s *Server) rdsSnapshots(c *gin.Context) ([]models.RDSSnapshot, error) {
+	return cached(s, c, "snapshots", "rds", s.aws.GetRDSSnapshots)
+}
+
+// stoppedCostReport returns the stopped-but-costing report
+func (s *Server) stoppedCostReport(c *gin.Context) (*models.StoppedCostReport, error) {
+	return cached(s, c, "stopped-costs", "", s.aws.GetStoppedCostReport)
+}
+
+// snapshotReport returns the snapshot cleanup report for a retention period
+func (s *Server) snapshotReport(c *gin.Context, maxAgeDays int) (*models.SnapshotReport, error) {
+	return cached(s, c, "snapshot-report", strconv.Itoa(maxAgeDays), func(ctx context.Context) (*models.SnapshotReport, error) {
+		return s.aws.GetSnapshotReport(ctx, maxAgeDays)
+	})
+}
+
+// costData returns cost by service for a time period
+func (s *Server) costData(c *gin.Context, start, end string) (*models.CostData, error) {
+	return cached(s, c, "cost", start+"/"+end, func(ctx context.Context) (*models.CostData, error) {
+		return s.aws.GetCostAndUsage(ctx, start, end)
+	})
+}
+
+// dataTransferCost returns the data transfer breakdown for a time period
+func (s *Server) dataTransferCost(c *gin.Context, start, end, granularity string) (*models.DataTransferReport, error) {
+	key := fmt.Sprintf("%s/%s/%s", start, end, granularity)
+	return cached(s, c, "data-transfer", key, func(ctx context.Context) (*models.DataTransferReport, error) {
+		return s.aws.GetDataTransferCost(ctx, start, end, granularity)
+	})
+}
+
+// accountID returns the ID of the AWS account the credentials belong to
+func (s *Server) accountID(c *gin.Context) (string, error) {
+	return cached(s, c, "account", "", s.aws.GetAccountID)
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/aws"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/export"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/listing"
+	"github.com/gin-gonic/gin"
+)
+
+// exportDatasets are the datasets /api/export can produce
+var exportDatasets = []string{
+	"ec2", "rds", "ebs", "log-groups", "public-ips", "ebs-snapshots", "rds-snapshots",
+	"stopped-costs", "recommendations", "cost", "data-transfer",
+}
+
+// exportParams are query parameters of /api/export that are not list filters
+var exportParams = append([]string{"start", "end", "granularity", "maxAgeDays"}, export.Params...)
+
+// costRow is one service's cost for one period of CostData, with a numeric amount for spreadsheets
+type costRow struct {
+	Service string  `json:"service"`
+	Date    string  `json:"date"`
+	Amount  float64 `json:"amount"`
+	Unit    string  `json:"unit"`
+}
+
+// exportData streams ?dataset= as CSV, XLSX or NDJSON (?format=), with the ?columns= selected.
+// List filters such as ?region= or ?tag:team= narrow the rows exported.
+func (s *Server) exportData(c *gin.Context) {
+	dataset := c.DefaultQuery("dataset", "ec2")
+	switch dataset {
+	case "ec2":
+		instances, err := s.ec2Instances(c, parseStates(c, "running"))
+		exportList(c, dataset, instances, err, "state")
+	case "rds":
+		instances, err := s.rdsInstances(c, parseStates(c, "available"))
+		exportList(c, dataset, instances, err, "state")
+	case "ebs":
+		volumes, err := s.ebsVolumes(c)
+		exportList(c, dataset, volumes, err)
+	case "log-groups":
+		logGroups, err := s.logGroups(c)
+		exportList(c, dataset, logGroups, err)
+	case "public-ips":
+		addresses, err := s.publicIPv4Addresses(c)
+		exportList(c, dataset, addresses, err)
+	case "ebs-snapshots":
+		snapshots, err := s.ebsSnapshots(c)
+		exportList(c, dataset, snapshots, err)
+	case "rds-snapshots":
+		snapshots, err := s.rdsSnapshots(c)
+		exportList(c, dataset, snapshots, err)
+	case "stopped-costs":
+		report, err := s.stoppedCostReport(c)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		exportList(c, dataset, report.Resources, nil)
+	case "recommendations":
+		maxAgeDays, err := parseMaxAgeDays(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		stopped, err := s.stoppedCostReport(c)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		snapshots, err := s.snapshotReport(c, maxAgeDays)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		exportList(c, dataset, aws.BuildRecommendations(stopped, snapshots), nil)
+	case "cost":
+		start, end := c.Query("start"), c.Query("end")
+		if start == "" || end == "" {
+			start, end = aws.GetDefaultDateRange()
+		}
+		costData, err := s.costData(c, start, end)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		rows := make([]costRow, 0, len(costData.Results))
+		for _, result := range costData.Results {
+			amount, _ := strconv.ParseFloat(result.Amount, 64)
+			rows = append(rows, costRow{Service: result.Service, Date: result.Date, Amount: amount, Unit: result.Unit})
+		}
+		exportList(c, dataset, rows, nil)
+	case "data-transfer":
+		start, end := c.Query("start"), c.Query("end")
+		if start == "" || end == "" {
+			start, end = aws.GetDefaultDateRange()
+		}
+		granularity := strings.ToUpper(c.DefaultQuery("granularity", "DAILY"))
+		if granularity != "DAILY" && granularity != "MONTHLY" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "granularity must be DAILY or MONTHLY"})
+			return
+		}
+		report, err := s.dataTransferCost(c, start, end, granularity)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		exportList(c, dataset, report.Usage, nil)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "dataset must be one of " + strings.Join(exportDatasets, ", ")})
+	}
+}
+
+// exportList filters records with the list query and streams them, or reports err
+func exportList[T any](c *gin.Context, dataset string, records []T, err error, skip ...string) {
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	records, err = listing.FilterQuery(c, records, append(skip, exportParams...)...)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	export.Respond(c, dataset, records)
+}
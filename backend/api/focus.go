@@ -0,0 +1,193 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/aws"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/focus"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/tabular"
+	"github.com/devesh-kumar/aws-resources-cost-board/models"
+	"github.com/gin-gonic/gin"
+)
+
+// maxFOCUSUpload is the largest FOCUS file accepted by /api/focus/import
+const maxFOCUSUpload = 256 << 20
+
+// exportFOCUS streams cost data as a FOCUS CSV or Parquet file (?format=). ?dataset=cost (the
+// default) exports CostData for ?start= to ?end=, imported exports the imported FOCUS rows for
+// the period and all exports both.
+func (s *Server) exportFOCUS(c *gin.Context) {
+	format, err := focus.ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	start, end := c.Query("start"), c.Query("end")
+	if start == "" || end == "" {
+		start, end = aws.GetDefaultDateRange()
+	}
+	startTime, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start must be a YYYY-MM-DD date"})
+		return
+	}
+	endTime, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end must be a YYYY-MM-DD date"})
+		return
+	}
+
+	dataset := c.DefaultQuery("dataset", "cost")
+	var rows []focus.Row
+	switch dataset {
+	case "cost", "all":
+		costData, err := s.costData(c, start, end)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		accountID, err := s.accountID(c)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		rows = costDataToFOCUS(costData, accountID)
+		if dataset == "all" {
+			rows = append(rows, importedNonAWS(s.focus.Rows(startTime, endTime))...)
+		}
+	case "imported":
+		rows = s.focus.Rows(startTime, endTime)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "dataset must be cost, imported or all"})
+		return
+	}
+
+	filename := fmt.Sprintf("focus-%s-%s-%s.%s", dataset, start, end, format)
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+	if err := focus.Write(c.Writer, format, rows); err != nil {
+		log.Printf("Error exporting FOCUS data: %v", err)
+	}
+}
+
+// importFOCUS imports a FOCUS CSV, CSV.gz or Parquet file, uploaded as the "file" field of a
+// multipart form or as the request body with ?name= giving the file name
+func (s *Server) importFOCUS(c *gin.Context) {
+	var name string
+	var body io.Reader
+	if file, err := c.FormFile("file"); err == nil {
+		opened, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer opened.Close()
+		name, body = file.Filename, opened
+	} else {
+		name, body = c.Query("name"), c.Request.Body
+	}
+
+	format, err := tabular.FormatOf(name)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(body, maxFOCUSUpload+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(data) > maxFOCUSUpload {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "FOCUS file is larger than 256 MB"})
+		return
+	}
+
+	dataset, err := s.focus.Import(name, format, data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, dataset)
+}
+
+// getFOCUSImports returns the imported FOCUS datasets
+func (s *Server) getFOCUSImports(c *gin.Context) {
+	c.JSON(http.StatusOK, s.focus.Datasets())
+}
+
+// deleteFOCUSImport removes an imported FOCUS dataset
+func (s *Server) deleteFOCUSImport(c *gin.Context) {
+	if !s.focus.Delete(c.Param("id")) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "import not found"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// importedNonAWS drops imported rows billed by AWS, which Cost Explorer already reports
+func importedNonAWS(rows []focus.Row) []focus.Row {
+	kept := rows[:0:0]
+	for _, row := range rows {
+		if !focus.IsAWS(row.ProviderName) {
+			kept = append(kept, row)
+		}
+	}
+	return kept
+}
+
+// withImportedCost returns a copy of costData with imported FOCUS spend for the same period
+// appended. AWS results are marked with their provider too. Imported AWS spend is left out, as
+// Cost Explorer already covers it.
+func (s *Server) withImportedCost(costData *models.CostData) *models.CostData {
+	combined := &models.CostData{
+		TimeStart: costData.TimeStart,
+		TimeEnd:   costData.TimeEnd,
+		Results:   make([]models.CostByService, 0, len(costData.Results)),
+	}
+	for _, result := range costData.Results {
+		result.Provider = "AWS"
+		combined.Results = append(combined.Results, result)
+	}
+
+	start, errStart := time.Parse("2006-01-02", costData.TimeStart)
+	end, errEnd := time.Parse("2006-01-02", costData.TimeEnd)
+	if errStart != nil || errEnd != nil {
+		return combined
+	}
+	for _, cost := range s.focus.CostByService(start, end) {
+		if focus.IsAWS(cost.Provider) {
+			continue
+		}
+		combined.Results = append(combined.Results, models.CostByService{
+			Service:  cost.Service,
+			Amount:   strconv.FormatFloat(cost.Amount, 'f', -1, 64),
+			Unit:     cost.Currency,
+			Date:     cost.Date,
+			Provider: cost.Provider,
+		})
+	}
+	return combined
+}
+
+// costDataToFOCUS converts daily Cost Explorer results into FOCUS rows
+func costDataToFOCUS(costData *models.CostData, accountID string) []focus.Row {
+	rows := make([]focus.Row, 0, len(costData.Results))
+	for _, result := range costData.Results {
+		start, err := time.Parse("2006-01-02", result.Date)
+		if err != nil {
+			continue
+		}
+		amount, _ := strconv.ParseFloat(result.Amount, 64)
+		row := focus.AWSRow(accountID, result.Service, start, start.AddDate(0, 0, 1), amount, result.Unit)
+		row.ChargeDescription = result.Service + " usage"
+		rows = append(rows, row)
+	}
+	return rows
+}
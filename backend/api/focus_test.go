@@ -0,0 +1,48 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/focus"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/tabular"
+	"github.com/devesh-kumar/aws-resources-cost-board/models"
+)
+
+const importedFOCUS = `BilledCost,BillingCurrency,ChargePeriodStart,ChargePeriodEnd,ProviderName,ServiceName
+12.5,USD,2026-09-01T00:00:00Z,2026-09-02T00:00:00Z,Google Cloud,Compute Engine
+40,USD,2026-09-01T00:00:00Z,2026-09-02T00:00:00Z,AWS,Amazon Elastic Compute Cloud - Compute
+`
+
+func TestWithImportedCostSkipsImportedAWSSpend(t *testing.T) {
+	store, err := focus.NewStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Import("billing.csv", tabular.CSV, []byte(importedFOCUS)); err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{focus: store}
+
+	combined := s.withImportedCost(&models.CostData{
+		TimeStart: "2026-09-01",
+		TimeEnd:   "2026-09-02",
+		Results: []models.CostByService{
+			{Service: "Amazon Elastic Compute Cloud - Compute", Amount: "40", Unit: "USD", Date: "2026-09-01"},
+		},
+	})
+
+	var providers []string
+	for _, result := range combined.Results {
+		providers = append(providers, result.Provider+" "+result.Amount)
+	}
+	if len(providers) != 2 || providers[0] != "AWS 40" || providers[1] != "Google Cloud 12.5" {
+		t.Errorf("results = %v, want Cost Explorer AWS spend and the Google Cloud import", providers)
+	}
+
+	september := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	rows := importedNonAWS(store.Rows(september, september.AddDate(0, 1, 0)))
+	if len(rows) != 1 || rows[0].ProviderName != "Google Cloud" {
+		t.Errorf("exported imported rows = %v", rows)
+	}
+}
@@ -1,62 +1,54 @@
 package api
 
 import (
-	"context"
+	"fmt"
 	"net/http"
-	"time"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/devesh-kumar/aws-resources-cost-board/aws"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/listing"
 	"github.com/devesh-kumar/aws-resources-cost-board/models"
 	"github.com/gin-gonic/gin"
 )
 
-// getEC2Instances returns all running EC2 instances
+// getEC2Instances returns EC2 instances, running ones unless ?state= says otherwise
 func (s *Server) getEC2Instances(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30000*time.Second)
-	defer cancel()
-
-	instances, err := s.aws.GetRunningEC2Instances(ctx)
+	instances, err := s.ec2Instances(c, parseStates(c, "running"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, instances)
+	listing.Respond(c, instances, "state")
 }
 
-// getRDSInstances returns all running RDS instances
+// getRDSInstances returns RDS instances, available ones unless ?state= says otherwise
 func (s *Server) getRDSInstances(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30000*time.Second)
-	defer cancel()
-
-	instances, err := s.aws.GetRunningRDSInstances(ctx)
+	instances, err := s.rdsInstances(c, parseStates(c, "available"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, instances)
+	listing.Respond(c, instances, "state")
 }
 
-// getEBSVolumes returns all EBS volumes
+// getEBSVolumes returns EBS volumes matching the list query
 func (s *Server) getEBSVolumes(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30000*time.Second)
-	defer cancel()
-
-	volumes, err := s.aws.GetEBSVolumes(ctx)
+	volumes, err := s.ebsVolumes(c)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, volumes)
+	listing.Respond(c, volumes)
 }
 
-// getCost returns cost data for the specified time period
+// getCost returns cost data for the specified time period. ?include=imported adds spend from
+// imported FOCUS files, marked with its provider.
 func (s *Server) getCost(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30000*time.Second)
-	defer cancel()
-
 	start := c.DefaultQuery("start", "")
 	end := c.DefaultQuery("end", "")
 
@@ -64,83 +56,137 @@ func (s *Server) getCost(c *gin.Context) {
 		start, end = aws.GetDefaultDateRange()
 	}
 
-	costData, err := s.aws.GetCostAndUsage(ctx, start, end)
+	costData, err := s.costData(c, start, end)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	if c.Query("include") == "imported" {
+		costData = s.withImportedCost(costData)
+	}
+
 	c.JSON(http.StatusOK, costData)
 }
 
+// getDataTransferCost returns data transfer cost by category with a trend over the time period
+func (s *Server) getDataTransferCost(c *gin.Context) {
+	start := c.DefaultQuery("start", "")
+	end := c.DefaultQuery("end", "")
+
+	if start == "" || end == "" {
+		start, end = aws.GetDefaultDateRange()
+	}
+
+	granularity := strings.ToUpper(c.DefaultQuery("granularity", "DAILY"))
+	if granularity != "DAILY" && granularity != "MONTHLY" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "granularity must be DAILY or MONTHLY"})
+		return
+	}
+
+	report, err := s.dataTransferCost(c, start, end, granularity)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
 // getResources returns all resources (EC2, RDS, EBS, CloudWatch Log Groups)
 func (s *Server) getResources(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30000*time.Second)
-	defer cancel()
-
-	ec2Instances, err := s.aws.GetRunningEC2Instances(ctx)
+	ec2Instances, err := s.ec2Instances(c, parseStates(c, "running"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	rdsInstances, err := s.aws.GetRunningRDSInstances(ctx)
+	rdsInstances, err := s.rdsInstances(c, parseStates(c, "available"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	ebsVolumes, err := s.aws.GetEBSVolumes(ctx)
+	ebsVolumes, err := s.ebsVolumes(c)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	logGroups, err := s.aws.GetCloudWatchLogGroups(ctx)
+	logGroups, err := s.logGroups(c)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"ec2":             ec2Instances,
-		"rds":             rdsInstances,
-		"ebs":             ebsVolumes,
-		"cloudwatch_logs": logGroups,
+	group, err := listing.NewGroup(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if ec2Instances, err = listing.GroupApply(group, "ec2", ec2Instances, "state"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if rdsInstances, err = listing.GroupApply(group, "rds", rdsInstances, "state"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if ebsVolumes, err = listing.GroupApply(group, "ebs", ebsVolumes); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if logGroups, err = listing.GroupApply(group, "logs", logGroups); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := group.Finish(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResourceInventory{
+		EC2Instances:        ec2Instances,
+		RDSInstances:        rdsInstances,
+		EBSVolumes:          ebsVolumes,
+		CloudWatchLogGroups: logGroups,
 	})
 }
 
 // getSummary returns a summary of resources and their costs
 func (s *Server) getSummary(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 3000*time.Second)
-	defer cancel()
+	ec2Instances, err := s.ec2Instances(c, parseStates(c, "running"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
-	ec2Instances, err := s.aws.GetRunningEC2Instances(ctx)
+	rdsInstances, err := s.rdsInstances(c, parseStates(c, "available"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	rdsInstances, err := s.aws.GetRunningRDSInstances(ctx)
+	ebsVolumes, err := s.ebsVolumes(c)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	ebsVolumes, err := s.aws.GetEBSVolumes(ctx)
+	logGroups, err := s.logGroups(c)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	logGroups, err := s.aws.GetCloudWatchLogGroups(ctx)
+	publicIPs, err := s.publicIPv4Addresses(c)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	start, end := aws.GetDefaultDateRange()
-	costData, err := s.aws.GetCostAndUsage(ctx, start, end)
+	costData, err := s.costData(c, start, end)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -151,22 +197,127 @@ func (s *Server) getSummary(c *gin.Context) {
 		RDSInstances:        rdsInstances,
 		EBSVolumes:          ebsVolumes,
 		CloudWatchLogGroups: logGroups,
+		PublicIPv4Addresses: publicIPs,
+		PublicIPv4Charges:   aws.SummarizePublicIPv4Charges(publicIPs),
 		CostData:            costData,
 	}
 
 	c.JSON(http.StatusOK, summary)
 }
 
-// getCloudWatchLogGroups returns all CloudWatch Log Groups
+// getCloudWatchLogGroups returns CloudWatch Log Groups matching the list query
 func (s *Server) getCloudWatchLogGroups(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 3000*time.Second)
-	defer cancel()
+	logGroups, err := s.logGroups(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
-	logGroups, err := s.aws.GetCloudWatchLogGroups(ctx)
+	listing.Respond(c, logGroups)
+}
+
+// getPublicIPv4Addresses returns all billed public IPv4 addresses and their charges per account and region
+func (s *Server) getPublicIPv4Addresses(c *gin.Context) {
+	addresses, err := s.publicIPv4Addresses(c)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, logGroups)
+	addresses, err = listing.FilterQuery(c, addresses)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PublicIPv4Report{
+		Addresses: addresses,
+		Charges:   aws.SummarizePublicIPv4Charges(addresses),
+	})
+}
+
+// getStoppedCostReport returns stopped instances that still cost money through storage and Elastic IPs
+func (s *Server) getStoppedCostReport(c *gin.Context) {
+	report, err := s.stoppedCostReport(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// getEBSSnapshots returns all EBS snapshots owned by the account
+func (s *Server) getEBSSnapshots(c *gin.Context) {
+	snapshots, err := s.ebsSnapshots(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	listing.Respond(c, snapshots)
+}
+
+// getRDSSnapshots returns all manual and automated RDS snapshots
+func (s *Server) getRDSSnapshots(c *gin.Context) {
+	snapshots, err := s.rdsSnapshots(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	listing.Respond(c, snapshots)
+}
+
+// getSnapshotReport returns orphaned snapshots and snapshots older than ?maxAgeDays= (default 90)
+func (s *Server) getSnapshotReport(c *gin.Context) {
+	maxAgeDays, err := parseMaxAgeDays(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := s.snapshotReport(c, maxAgeDays)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// parseMaxAgeDays reads the ?maxAgeDays= snapshot retention period, defaulting to 90 days
+func parseMaxAgeDays(c *gin.Context) (int, error) {
+	raw := c.Query("maxAgeDays")
+	if raw == "" {
+		return aws.DefaultSnapshotMaxAgeDays, nil
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("maxAgeDays must be a non-negative integer")
+	}
+	return days, nil
+}
+
+// parseStates reads the comma-separated ?state= query parameter, falling back to defaults.
+// The value "all" disables state filtering. States are sorted and deduplicated, so the same set
+// shares one cache entry however it is written.
+func parseStates(c *gin.Context, defaults ...string) []string {
+	raw := c.Query("state")
+	if raw == "" {
+		return defaults
+	}
+	if raw == "all" {
+		return nil
+	}
+
+	var states []string
+	for _, state := range strings.Split(raw, ",") {
+		state = strings.TrimSpace(state)
+		if state != "" {
+			states = append(states, state)
+		}
+	}
+	slices.Sort(states)
+	return slices.Compact(states)
 }
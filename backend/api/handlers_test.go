@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseStates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	for _, test := range []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"running"}},
+		{"state=all", nil},
+		{"state=stopped,running", []string{"running", "stopped"}},
+		{"state=running,%20stopped,running,", []string{"running", "stopped"}},
+	} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/?"+test.query, nil)
+		if got := parseStates(c, "running"); !slices.Equal(got, test.want) {
+			t.Errorf("%s: states = %v, want %v", test.query, got, test.want)
+		}
+	}
+}
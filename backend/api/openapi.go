@@ -0,0 +1,108 @@
+package api
+
+import (
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/focus"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/openapi"
+	"github.com/devesh-kumar/aws-resources-cost-board/models"
+)
+
+// Query parameters shared by several routes
+var (
+	freshParam = openapi.Param{Name: "fresh", Type: "boolean", Description: "Bypass the cache"}
+	startParam = openapi.Param{Name: "start", Description: "Start date (YYYY-MM-DD), with end; defaults to the last 30 days"}
+	endParam   = openapi.Param{Name: "end", Description: "End date (YYYY-MM-DD), exclusive"}
+)
+
+// stateParam returns the ?state= parameter of a list whose default is the given state
+func stateParam(defaultState string) openapi.Param {
+	return openapi.Param{Name: "state", Description: "Comma-separated states, or all; " + defaultState + " by default"}
+}
+
+// routes describes every endpoint of the server for the OpenAPI document. Keep it in step with
+// registerRoutes; routes missing here are logged at startup.
+var routes = []openapi.Route{
+	{Method: "GET", Path: "/resources", ID: "getResources", Tag: "resources", List: true,
+		Summary:  "Get EC2 instances, RDS instances, EBS volumes and log groups; limit applies to each list",
+		Query:    []openapi.Param{stateParam("running EC2 and available RDS instances"), freshParam},
+		Response: models.ResourceInventory{}},
+	{Method: "GET", Path: "/ec2", ID: "listEC2Instances", Tag: "resources", List: true,
+		Summary:  "List EC2 instances",
+		Query:    []openapi.Param{stateParam("running"), freshParam},
+		Response: []models.EC2Instance{}},
+	{Method: "GET", Path: "/rds", ID: "listRDSInstances", Tag: "resources", List: true,
+		Summary:  "List RDS instances",
+		Query:    []openapi.Param{stateParam("available"), freshParam},
+		Response: []models.RDSInstance{}},
+	{Method: "GET", Path: "/ebs", ID: "listEBSVolumes", Tag: "resources", List: true,
+		Summary: "List EBS volumes", Query: []openapi.Param{freshParam}, Response: []models.EBSVolume{}},
+	{Method: "GET", Path: "/public-ips", ID: "getPublicIPv4Addresses", Tag: "resources",
+		Summary: "Get billed public IPv4 addresses and their charges per account and region",
+		Query:   []openapi.Param{freshParam}, Response: models.PublicIPv4Report{}},
+	{Method: "GET", Path: "/snapshots/ebs", ID: "listEBSSnapshots", Tag: "resources", List: true,
+		Summary: "List EBS snapshots", Query: []openapi.Param{freshParam}, Response: []models.EBSSnapshot{}},
+	{Method: "GET", Path: "/snapshots/rds", ID: "listRDSSnapshots", Tag: "resources", List: true,
+		Summary: "List RDS snapshots", Query: []openapi.Param{freshParam}, Response: []models.RDSSnapshot{}},
+	{Method: "GET", Path: "/cloudwatch/log-groups", ID: "listLogGroups", Tag: "resources", List: true,
+		Summary: "List CloudWatch log groups", Query: []openapi.Param{freshParam}, Response: []models.CloudWatchLogGroup{}},
+	{Method: "GET", Path: "/cost", ID: "getCost", Tag: "costs",
+		Summary: "Get daily cost by service",
+		Query: []openapi.Param{startParam, endParam,
+			{Name: "include", Enum: []string{"imported"}, Description: "Add spend from imported FOCUS files"},
+			freshParam},
+		Response: models.CostData{}},
+	{Method: "GET", Path: "/cost/data-transfer", ID: "getDataTransferCost", Tag: "costs",
+		Summary: "Get data transfer cost by category with a trend",
+		Query: []openapi.Param{startParam, endParam,
+			{Name: "granularity", Enum: []string{"DAILY", "MONTHLY"}, Description: "Trend period, DAILY by default"},
+			freshParam},
+		Response: models.DataTransferReport{}},
+	{Method: "GET", Path: "/summary", ID: "getSummary", Tag: "resources",
+		Summary:  "Get a summary of resources and their costs",
+		Query:    []openapi.Param{stateParam("running EC2 and available RDS instances"), freshParam},
+		Response: models.ResourcesSummary{}},
+	{Method: "GET", Path: "/reports/stopped-costs", ID: "getStoppedCostReport", Tag: "reports",
+		Summary: "Get stopped instances that still cost money through storage and Elastic IPs",
+		Query:   []openapi.Param{freshParam}, Response: models.StoppedCostReport{}},
+	{Method: "GET", Path: "/reports/snapshots", ID: "getSnapshotReport", Tag: "reports",
+		Summary: "Get orphaned snapshots and snapshots older than the retention policy",
+		Query: []openapi.Param{
+			{Name: "maxAgeDays", Type: "integer", Description: "Retention policy in days, 90 by default"},
+			freshParam},
+		Response: models.SnapshotReport{}},
+	{Method: "GET", Path: "/export", ID: "exportData", Tag: "exports",
+		Summary: "Download a dataset as CSV, XLSX or JSON Lines",
+		Query: []openapi.Param{
+			{Name: "dataset", Description: "Dataset to export, ec2 by default", Enum: []string{
+				"ec2", "rds", "ebs", "log-groups", "public-ips", "ebs-snapshots", "rds-snapshots",
+				"stopped-costs", "recommendations", "cost", "data-transfer"}},
+			{Name: "format", Enum: []string{"csv", "xlsx", "ndjson"}, Description: "File format, csv by default"},
+			{Name: "columns", Description: "Comma-separated columns to export, in order"},
+			startParam, endParam, freshParam},
+		Content: []string{"text/csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/x-ndjson"}},
+	{Method: "GET", Path: "/focus/export", ID: "exportFOCUS", Tag: "focus",
+		Summary: "Download cost data as a FOCUS file",
+		Query: []openapi.Param{
+			{Name: "dataset", Enum: []string{"cost", "imported", "all"}, Description: "Rows to export, cost by default"},
+			{Name: "format", Enum: []string{"csv", "parquet"}, Description: "File format, csv by default"},
+			startParam, endParam},
+		Content: []string{"text/csv", "application/vnd.apache.parquet"}},
+	{Method: "POST", Path: "/focus/import", ID: "importFOCUS", Tag: "focus", Status: 201,
+		Summary:     "Import a FOCUS CSV, CSV.gz or Parquet file",
+		Query:       []openapi.Param{{Name: "name", Description: "File name of a raw request body, such as azure.csv.gz"}},
+		BodyContent: []string{"multipart/form-data", "application/octet-stream"},
+		Response:    focus.Dataset{}},
+	{Method: "GET", Path: "/focus/imports", ID: "listFOCUSImports", Tag: "focus",
+		Summary: "List imported FOCUS files", Response: []focus.Dataset{}},
+	{Method: "DELETE", Path: "/focus/imports/:id", ID: "deleteFOCUSImport", Tag: "focus", Status: 204,
+		Summary: "Delete an imported FOCUS file"},
+	{Method: "GET", Path: "/openapi.json", ID: "getOpenAPI", Tag: "meta",
+		Summary: "Get this OpenAPI document", Response: map[string]any{}},
+}
+
+// Spec returns the OpenAPI document of the server
+func Spec() *openapi.Document {
+	doc := openapi.New("AWS Resources Cost Board API", "1.0.0", "/api")
+	doc.Info.Description = "Inventory, cost and report API of the cost board server."
+	doc.Add(routes...)
+	return doc
+}
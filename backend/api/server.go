@@ -1,7 +1,13 @@
 package api
 
 import (
+	"log"
+	"os"
+
 	"github.com/devesh-kumar/aws-resources-cost-board/aws"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/cache"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/focus"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/listing"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
@@ -10,21 +16,32 @@ import (
 type Server struct {
 	router *gin.Engine
 	aws    *aws.ClientsConfig
+	cache  *cache.Cache
+	focus  *focus.Store
 }
 
 // NewServer creates a new API server
 func NewServer(aws *aws.ClientsConfig) *Server {
+	// Imported FOCUS files are kept in FOCUS_IMPORT_DIR when set, otherwise in memory
+	focusStore, err := focus.NewStore(os.Getenv("FOCUS_IMPORT_DIR"))
+	if err != nil {
+		log.Printf("Error loading imported FOCUS data: %v", err)
+		focusStore, _ = focus.NewStore("")
+	}
+
 	server := &Server{
 		router: gin.Default(),
 		aws:    aws,
+		cache:  cache.New(),
+		focus:  focusStore,
 	}
 
 	// Configure CORS
 	server.router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000"},
-		AllowMethods:     []string{"GET", "POST"},
+		AllowMethods:     []string{"GET", "POST", "DELETE"},
 		AllowHeaders:     []string{"Origin", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
+		ExposeHeaders:    append([]string{"Content-Length", "Content-Disposition"}, listing.Headers...),
 		AllowCredentials: true,
 	}))
 
@@ -47,9 +64,26 @@ func (s *Server) registerRoutes() {
 		api.GET("/ec2", s.getEC2Instances)
 		api.GET("/rds", s.getRDSInstances)
 		api.GET("/ebs", s.getEBSVolumes)
+		api.GET("/public-ips", s.getPublicIPv4Addresses)
+		api.GET("/snapshots/ebs", s.getEBSSnapshots)
+		api.GET("/snapshots/rds", s.getRDSSnapshots)
 		api.GET("/cloudwatch/log-groups", s.getCloudWatchLogGroups)
 		api.GET("/cost", s.getCost)
+		api.GET("/cost/data-transfer", s.getDataTransferCost)
 		api.GET("/summary", s.getSummary)
+		api.GET("/reports/stopped-costs", s.getStoppedCostReport)
+		api.GET("/reports/snapshots", s.getSnapshotReport)
+		api.GET("/export", s.exportData)
+		api.GET("/focus/export", s.exportFOCUS)
+		api.POST("/focus/import", s.importFOCUS)
+		api.GET("/focus/imports", s.getFOCUSImports)
+		api.DELETE("/focus/imports/:id", s.deleteFOCUSImport)
+	}
+
+	spec := Spec()
+	api.GET("/openapi.json", spec.Handler())
+	for _, route := range spec.Undocumented(s.router.Routes()) {
+		log.Printf("Route %s is missing from the OpenAPI document", route)
 	}
 }
 
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/aws"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/cache"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/focus"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/tabular"
+	"github.com/devesh-kumar/aws-resources-cost-board/models"
+)
+
+// seed stores a collector's value in the server's cache, so handlers serve it without AWS
+func seed[T any](t *testing.T, s *Server, collector, key string, value T) {
+	t.Helper()
+	fetch := func(context.Context) (T, error) { return value, nil }
+	if _, err := cache.Get(context.Background(), s.cache, collector+":"+key, defaultCachePolicy, false, fetch); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestServer returns a server whose AWS data is stubbed in its cache, with an imported FOCUS
+// file. Its AWS clients are not set, so a request for data that was not stubbed fails.
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	store, err := focus.NewStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Import("billing.csv", tabular.CSV, []byte(importedFOCUS)); err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{router: gin.New(), cache: cache.New(), focus: store}
+	s.registerRoutes()
+
+	launched := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	start, end := aws.GetDefaultDateRange()
+	seed(t, s, "ec2", "running", []models.EC2Instance{
+		{ID: "i-1", Name: "web", Type: "t3.micro", LaunchTime: launched, State: "running"},
+	})
+	seed(t, s, "rds", "available", []models.RDSInstance{
+		{ID: "db", Class: "db.t3.micro", Engine: "postgres", Status: "available", AllocatedStorage: 20},
+	})
+	seed(t, s, "ebs", "", []models.EBSVolume{
+		{ID: "vol-1", Size: 100, VolumeType: "gp3", State: "in-use", CreationTime: launched, AttachedTo: "i-1"},
+	})
+	seed(t, s, "log-groups", "", []models.CloudWatchLogGroup{
+		{Name: "/aws/lambda/function", ARN: "arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/function", StoredBytes: 1 << 30},
+	})
+	seed(t, s, "public-ips", "", []models.PublicIPv4Address{
+		{PublicIP: "198.51.100.1", Kind: "elastic-ip", AccountID: "123456789012", Region: "us-east-1", MonthlyCost: 3.6},
+	})
+	seed(t, s, "snapshots", "ebs", []models.EBSSnapshot{
+		{ID: "snap-1", VolumeID: "vol-2", VolumeSize: 100, State: "completed", StartTime: launched, AgeDays: 400, MonthlyCost: 5},
+	})
+	seed(t, s, "snapshots", "rds", []models.RDSSnapshot{
+		{ID: "db-snap", DBInstanceID: "db", SnapshotType: "manual", CreationTime: launched, SourceExists: true},
+	})
+	seed(t, s, "stopped-costs", "", &models.StoppedCostReport{
+		Resources: []models.StoppedResourceCost{
+			{ID: "i-2", ResourceType: "EC2Instance", State: "stopped", VolumeIDs: []string{"vol-3"}, StorageGB: 50, MonthlyCost: 4},
+		},
+		TotalMonthlyCost: 4,
+		GeneratedAt:      launched,
+	})
+	seed(t, s, "snapshot-report", strconv.Itoa(aws.DefaultSnapshotMaxAgeDays), &models.SnapshotReport{
+		MaxAgeDays: aws.DefaultSnapshotMaxAgeDays,
+		Candidates: []models.SnapshotCleanupCandidate{
+			{ID: "snap-1", ResourceType: "EBSSnapshot", SourceID: "vol-2", AgeDays: 400, Reasons: []string{"orphaned"}, MonthlyCost: 5},
+		},
+		OrphanedCount: 1,
+		GeneratedAt:   launched,
+	})
+	seed(t, s, "cost", start+"/"+end, &models.CostData{
+		TimeStart: start,
+		TimeEnd:   end,
+		Results:   []models.CostByService{{Service: "Amazon Elastic Compute Cloud - Compute", Amount: "40", Unit: "USD", Date: start}},
+	})
+	seed(t, s, "data-transfer", start+"/"+end+"/DAILY", &models.DataTransferReport{
+		TimeStart:   start,
+		TimeEnd:     end,
+		Granularity: "DAILY",
+		Unit:        "USD",
+		ByCategory:  map[string]float64{"internet": 1.5},
+		Usage:       []models.DataTransferUsage{{Category: "internet", Service: "EC2", UsageType: "DataTransfer-Out-Bytes", UsageGB: 16, Amount: 1.5}},
+		Trend:       []models.DataTransferTrendPoint{{Date: start, ByCategory: map[string]float64{"internet": 1.5}, Total: 1.5}},
+		Total:       1.5,
+	})
+	seed(t, s, "account", "", "123456789012")
+	return s
+}
+
+func TestDocumentedResponses(t *testing.T) {
+	s := newTestServer(t)
+
+	// Variants of a path that return a different shape
+	variants := map[string][]string{
+		"/cost": {"", "include=imported"},
+	}
+
+	spec := Spec()
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		op := (*spec.Paths[path])["get"]
+		if op == nil {
+			continue
+		}
+		queries := variants[path]
+		if queries == nil {
+			queries = []string{""}
+		}
+		for _, query := range queries {
+			target := "/api" + path
+			if query != "" {
+				target += "?" + query
+			}
+			t.Run(target, func(t *testing.T) {
+				recorder := httptest.NewRecorder()
+				s.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+				if recorder.Code != http.StatusOK {
+					t.Fatalf("GET %s = %d %s", target, recorder.Code, recorder.Body)
+				}
+				if err := spec.ValidateResponse(http.MethodGet, path, recorder.Code, recorder.Body.Bytes()); err != nil {
+					t.Errorf("GET %s does not match the document: %v", target, err)
+				}
+			})
+		}
+	}
+}
+
+func TestResourcesPagesEachList(t *testing.T) {
+	s := newTestServer(t)
+
+	recorder := httptest.NewRecorder()
+	s.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/resources?limit=1", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /api/resources = %d %s", recorder.Code, recorder.Body)
+	}
+	if total := recorder.Header().Get("X-Total-Count"); total != "4" {
+		t.Errorf("total = %s, want 4", total)
+	}
+	if err := Spec().ValidateResponse(http.MethodGet, "/resources", recorder.Code, recorder.Body.Bytes()); err != nil {
+		t.Error(err)
+	}
+
+	recorder = httptest.NewRecorder()
+	s.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/resources?region=us-east-1", nil))
+	if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), "region") {
+		t.Errorf("filter on a field no list has = %d %s, want 400", recorder.Code, recorder.Body)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
 // ClientsConfig holds all AWS service clients
@@ -18,6 +19,7 @@ type ClientsConfig struct {
 	RDSClient            *rds.Client
 	CostExplorerClient   *costexplorer.Client
 	CloudWatchLogsClient *cloudwatchlogs.Client
+	STSClient            *sts.Client
 	// EC2Client is reused for EBS operations since they're part of the same service
 }
 
@@ -63,5 +65,6 @@ func NewClientsConfig() *ClientsConfig {
 		RDSClient:            rds.NewFromConfig(cfg),
 		CostExplorerClient:   costexplorer.NewFromConfig(cfg),
 		CloudWatchLogsClient: cloudwatchlogs.NewFromConfig(cfg),
+		STSClient:            sts.NewFromConfig(cfg),
 	}
 }
@@ -0,0 +1,149 @@
+package aws
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/devesh-kumar/aws-resources-cost-board/models"
+)
+
+// Data transfer categories
+const (
+	DataTransferInternetEgress = "internet-egress"
+	DataTransferInterRegion    = "inter-region"
+	DataTransferInterAZ        = "inter-az"
+	DataTransferNATProcessing  = "nat-processing"
+	DataTransferCloudFront     = "cloudfront"
+)
+
+// cloudFrontService is the Cost Explorer SERVICE value of CloudFront
+const cloudFrontService = "Amazon CloudFront"
+
+// GetDataTransferCost returns data transfer cost for the specified time period, grouping Cost
+// Explorer usage types into transfer categories. Granularity is DAILY or MONTHLY.
+func (c *ClientsConfig) GetDataTransferCost(ctx context.Context, startDate, endDate, granularity string) (*models.DataTransferReport, error) {
+	input := &costexplorer.GetCostAndUsageInput{
+		TimePeriod: &types.DateInterval{
+			Start: &startDate,
+			End:   &endDate,
+		},
+		Granularity: types.Granularity(granularity),
+		Metrics:     []string{"BlendedCost", "UsageQuantity"},
+		GroupBy: []types.GroupDefinition{
+			{
+				Type: types.GroupDefinitionTypeDimension,
+				Key:  stringPtr("SERVICE"),
+			},
+			{
+				Type: types.GroupDefinitionTypeDimension,
+				Key:  stringPtr("USAGE_TYPE"),
+			},
+		},
+	}
+
+	report := &models.DataTransferReport{
+		TimeStart:   startDate,
+		TimeEnd:     endDate,
+		Granularity: granularity,
+		Unit:        "USD",
+		ByCategory:  make(map[string]float64),
+		Usage:       make([]models.DataTransferUsage, 0),
+		Trend:       make([]models.DataTransferTrendPoint, 0),
+	}
+	usage := make(map[string]*models.DataTransferUsage)
+
+	// With GroupBy, the groups of one period can be split across pages, so points are merged by
+	// period start rather than appended per page
+	trendIndex := make(map[string]int)
+	for {
+		result, err := c.CostExplorerClient.GetCostAndUsage(ctx, input)
+		if err != nil {
+			log.Printf("Error getting data transfer cost and usage: %v", err)
+			return nil, err
+		}
+
+		for _, resultByTime := range result.ResultsByTime {
+			date := *resultByTime.TimePeriod.Start
+			index, ok := trendIndex[date]
+			if !ok {
+				index = len(report.Trend)
+				trendIndex[date] = index
+				report.Trend = append(report.Trend, models.DataTransferTrendPoint{
+					Date:       date,
+					ByCategory: make(map[string]float64),
+				})
+			}
+			point := &report.Trend[index]
+
+			for _, group := range resultByTime.Groups {
+				service, usageType := group.Keys[0], group.Keys[1]
+				category := ClassifyDataTransfer(service, usageType)
+				if category == "" {
+					continue
+				}
+
+				cost := group.Metrics["BlendedCost"]
+				amount, _ := strconv.ParseFloat(*cost.Amount, 64)
+				quantity, _ := strconv.ParseFloat(*group.Metrics["UsageQuantity"].Amount, 64)
+				if cost.Unit != nil {
+					report.Unit = *cost.Unit
+				}
+
+				point.ByCategory[category] += amount
+				point.Total += amount
+				report.ByCategory[category] += amount
+				report.Total += amount
+
+				key := service + "/" + usageType
+				if usage[key] == nil {
+					usage[key] = &models.DataTransferUsage{Category: category, Service: service, UsageType: usageType}
+				}
+				usage[key].Amount += amount
+				usage[key].UsageGB += quantity
+			}
+		}
+
+		if result.NextPageToken == nil {
+			break
+		}
+		input.NextPageToken = result.NextPageToken
+	}
+
+	for _, item := range usage {
+		report.Usage = append(report.Usage, *item)
+	}
+	sort.Slice(report.Usage, func(i, j int) bool {
+		return report.Usage[i].Amount > report.Usage[j].Amount
+	})
+
+	return report, nil
+}
+
+// ClassifyDataTransfer returns the data transfer category of a Cost Explorer usage type, or an
+// empty string when the usage type is not data transfer. Usage types carry a region prefix such
+// as "USE1-" or "EU-", so they are matched on their suffix.
+func ClassifyDataTransfer(service, usageType string) string {
+	switch {
+	case service == cloudFrontService:
+		if strings.Contains(usageType, "Bytes") {
+			return DataTransferCloudFront
+		}
+		return ""
+	case strings.Contains(usageType, "CloudFront-Out-Bytes"):
+		return DataTransferCloudFront
+	case strings.Contains(usageType, "NatGateway-Bytes"):
+		return DataTransferNATProcessing
+	case strings.Contains(usageType, "DataTransfer-Regional-Bytes"), strings.Contains(usageType, "DataTransfer-xAZ"):
+		return DataTransferInterAZ
+	case strings.Contains(usageType, "-AWS-Out-Bytes"), strings.Contains(usageType, "-AWS-In-Bytes"):
+		return DataTransferInterRegion
+	case strings.Contains(usageType, "DataTransfer-Out-Bytes"):
+		return DataTransferInternetEgress
+	}
+	return ""
+}
@@ -11,32 +11,42 @@ import (
 
 // GetRunningEC2Instances returns all running EC2 instances
 func (c *ClientsConfig) GetRunningEC2Instances(ctx context.Context) ([]models.EC2Instance, error) {
-	input := &ec2.DescribeInstancesInput{
-		Filters: []types.Filter{
+	return c.GetEC2Instances(ctx, "running")
+}
+
+// GetEC2Instances returns EC2 instances in any of the given states.
+// When no states are given, instances in every state are returned.
+func (c *ClientsConfig) GetEC2Instances(ctx context.Context, states ...string) ([]models.EC2Instance, error) {
+	input := &ec2.DescribeInstancesInput{}
+	if len(states) > 0 {
+		input.Filters = []types.Filter{
 			{
 				Name:   stringPtr("instance-state-name"),
-				Values: []string{"running"},
+				Values: states,
 			},
-		},
-	}
-
-	result, err := c.EC2Client.DescribeInstances(ctx, input)
-	if err != nil {
-		log.Printf("Error describing EC2 instances: %v", err)
-		return nil, err
+		}
 	}
 
 	var instances []models.EC2Instance
-	for _, reservation := range result.Reservations {
-		for _, instance := range reservation.Instances {
-			name := getNameFromTags(instance.Tags)
-			instances = append(instances, models.EC2Instance{
-				ID:         *instance.InstanceId,
-				Name:       name,
-				Type:       string(instance.InstanceType),
-				LaunchTime: *instance.LaunchTime,
-				State:      string(instance.State.Name),
-			})
+	paginator := ec2.NewDescribeInstancesPaginator(c.EC2Client, input)
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing EC2 instances: %v", err)
+			return nil, err
+		}
+
+		for _, reservation := range result.Reservations {
+			for _, instance := range reservation.Instances {
+				name := getNameFromTags(instance.Tags)
+				instances = append(instances, models.EC2Instance{
+					ID:         *instance.InstanceId,
+					Name:       name,
+					Type:       string(instance.InstanceType),
+					LaunchTime: *instance.LaunchTime,
+					State:      string(instance.State.Name),
+				})
+			}
 		}
 	}
 
@@ -0,0 +1,151 @@
+package aws
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+	"github.com/devesh-kumar/aws-resources-cost-board/models"
+)
+
+// GetPublicIPv4Addresses returns every billed public IPv4 address: Elastic IPs, whether
+// associated or not, and auto-assigned public IPs on instances and network interfaces
+func (c *ClientsConfig) GetPublicIPv4Addresses(ctx context.Context) ([]models.PublicIPv4Address, error) {
+	accountID, err := c.GetAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	region := c.EC2Client.Options().Region
+
+	result, err := c.EC2Client.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{})
+	if err != nil {
+		log.Printf("Error describing Elastic IPs: %v", err)
+		return nil, err
+	}
+
+	var addresses []models.PublicIPv4Address
+	seen := make(map[string]bool)
+
+	for _, address := range result.Addresses {
+		if address.PublicIp == nil {
+			continue
+		}
+		seen[*address.PublicIp] = true
+
+		addresses = append(addresses, models.PublicIPv4Address{
+			PublicIP:           *address.PublicIp,
+			Kind:               "elastic",
+			AllocationID:       derefString(address.AllocationId),
+			Name:               getNameFromTags(address.Tags),
+			InstanceID:         derefString(address.InstanceId),
+			NetworkInterfaceID: derefString(address.NetworkInterfaceId),
+			Associated:         address.AssociationId != nil,
+			AccountID:          accountID,
+			Region:             region,
+			MonthlyCost:        pricing.PublicIPv4Monthly(),
+		})
+	}
+
+	paginator := ec2.NewDescribeNetworkInterfacesPaginator(c.EC2Client, &ec2.DescribeNetworkInterfacesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing network interfaces: %v", err)
+			return nil, err
+		}
+
+		for _, eni := range page.NetworkInterfaces {
+			instanceID := ""
+			if eni.Attachment != nil {
+				instanceID = derefString(eni.Attachment.InstanceId)
+			}
+
+			for _, private := range eni.PrivateIpAddresses {
+				if private.Association == nil || private.Association.PublicIp == nil {
+					continue
+				}
+				ip := *private.Association.PublicIp
+				if seen[ip] {
+					continue
+				}
+				seen[ip] = true
+
+				owner := derefString(eni.OwnerId)
+				if owner == "" {
+					owner = accountID
+				}
+
+				addresses = append(addresses, models.PublicIPv4Address{
+					PublicIP:           ip,
+					Kind:               "auto-assigned",
+					Name:               getNameFromTags(eni.TagSet),
+					InstanceID:         instanceID,
+					NetworkInterfaceID: derefString(eni.NetworkInterfaceId),
+					Associated:         true,
+					AccountID:          owner,
+					Region:             region,
+					MonthlyCost:        pricing.PublicIPv4Monthly(),
+				})
+			}
+		}
+	}
+
+	return addresses, nil
+}
+
+// SummarizePublicIPv4Charges totals monthly public IPv4 charges per account and region
+func SummarizePublicIPv4Charges(addresses []models.PublicIPv4Address) []models.PublicIPv4Charges {
+	type key struct{ account, region string }
+	totals := make(map[key]*models.PublicIPv4Charges)
+
+	for _, address := range addresses {
+		k := key{address.AccountID, address.Region}
+		charges, ok := totals[k]
+		if !ok {
+			charges = &models.PublicIPv4Charges{AccountID: address.AccountID, Region: address.Region}
+			totals[k] = charges
+		}
+
+		charges.AddressCount++
+		if address.Kind == "elastic" {
+			charges.ElasticIPCount++
+			if !address.Associated {
+				charges.UnassociatedCount++
+				charges.UnassociatedMonthlyCost += address.MonthlyCost
+			}
+		}
+		charges.MonthlyCost += address.MonthlyCost
+	}
+
+	summary := make([]models.PublicIPv4Charges, 0, len(totals))
+	for _, charges := range totals {
+		summary = append(summary, *charges)
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].AccountID != summary[j].AccountID {
+			return summary[i].AccountID < summary[j].AccountID
+		}
+		return summary[i].Region < summary[j].Region
+	})
+	return summary
+}
+
+// GetAccountID returns the ID of the AWS account the credentials belong to
+func (c *ClientsConfig) GetAccountID(ctx context.Context) (string, error) {
+	identity, err := c.STSClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		log.Printf("Error getting caller identity: %v", err)
+		return "", err
+	}
+	return derefString(identity.Account), nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
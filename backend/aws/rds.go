@@ -10,24 +10,52 @@ import (
 
 // GetRunningRDSInstances returns all running RDS instances
 func (c *ClientsConfig) GetRunningRDSInstances(ctx context.Context) ([]models.RDSInstance, error) {
-	input := &rds.DescribeDBInstancesInput{}
-	result, err := c.RDSClient.DescribeDBInstances(ctx, input)
-	if err != nil {
-		log.Printf("Error describing RDS instances: %v", err)
-		return nil, err
+	return c.GetRDSInstances(ctx, "available")
+}
+
+// GetRDSInstances returns RDS instances whose status matches any of the given states.
+// "running" is accepted as an alias for "available" so the same filter works for EC2 and RDS.
+// When no states are given, instances in every state are returned.
+func (c *ClientsConfig) GetRDSInstances(ctx context.Context, states ...string) ([]models.RDSInstance, error) {
+	wanted := make(map[string]bool, len(states))
+	for _, state := range states {
+		if state == "running" {
+			state = "available"
+		}
+		wanted[state] = true
 	}
 
 	var instances []models.RDSInstance
-	for _, instance := range result.DBInstances {
-		// Only include instances that are available
-		if instance.DBInstanceStatus != nil && *instance.DBInstanceStatus == "available" {
+	paginator := rds.NewDescribeDBInstancesPaginator(c.RDSClient, &rds.DescribeDBInstancesInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing RDS instances: %v", err)
+			return nil, err
+		}
+
+		for _, instance := range result.DBInstances {
+			status := ""
+			if instance.DBInstanceStatus != nil {
+				status = *instance.DBInstanceStatus
+			}
+			if len(wanted) > 0 && !wanted[status] {
+				continue
+			}
+
+			storageType := ""
+			if instance.StorageType != nil {
+				storageType = *instance.StorageType
+			}
+
 			instances = append(instances, models.RDSInstance{
 				ID:               *instance.DBInstanceIdentifier,
 				Class:            *instance.DBInstanceClass,
 				Engine:           *instance.Engine,
 				EngineVersion:    *instance.EngineVersion,
-				Status:           *instance.DBInstanceStatus,
+				Status:           status,
 				AllocatedStorage: *instance.AllocatedStorage,
+				StorageType:      storageType,
 			})
 		}
 	}
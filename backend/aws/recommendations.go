@@ -0,0 +1,43 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/models"
+)
+
+// BuildRecommendations turns the stopped-cost and snapshot reports into cost saving actions
+func BuildRecommendations(stopped *models.StoppedCostReport, snapshots *models.SnapshotReport) []models.Recommendation {
+	recommendations := make([]models.Recommendation, 0, len(stopped.Resources)+len(snapshots.Candidates))
+
+	for _, resource := range stopped.Resources {
+		var reasons []string
+		if resource.StorageGB > 0 {
+			reasons = append(reasons, fmt.Sprintf("%d GB of storage billed while stopped", resource.StorageGB))
+		}
+		if len(resource.ElasticIPs) > 0 {
+			reasons = append(reasons, fmt.Sprintf("%d Elastic IPs billed while stopped", len(resource.ElasticIPs)))
+		}
+		recommendations = append(recommendations, models.Recommendation{
+			ResourceID:     resource.ID,
+			Name:           resource.Name,
+			ResourceType:   resource.ResourceType,
+			Action:         "terminate-stopped-instance",
+			Reasons:        reasons,
+			MonthlySavings: resource.MonthlyCost,
+		})
+	}
+
+	for _, candidate := range snapshots.Candidates {
+		recommendations = append(recommendations, models.Recommendation{
+			ResourceID:     candidate.ID,
+			Name:           candidate.ID,
+			ResourceType:   candidate.ResourceType,
+			Action:         "delete-snapshot",
+			Reasons:        candidate.Reasons,
+			MonthlySavings: candidate.MonthlyCost,
+		})
+	}
+
+	return recommendations
+}
@@ -0,0 +1,105 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+	"github.com/devesh-kumar/aws-resources-cost-board/models"
+)
+
+// GetStoppedCostReport returns stopped EC2 and RDS instances that still accrue charges
+// for their attached storage and Elastic IPs
+func (c *ClientsConfig) GetStoppedCostReport(ctx context.Context) (*models.StoppedCostReport, error) {
+	instances, err := c.GetEC2Instances(ctx, "stopped")
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := c.GetEBSVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, err := c.GetPublicIPv4Addresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rdsInstances, err := c.GetRDSInstances(ctx, "stopped")
+	if err != nil {
+		return nil, err
+	}
+
+	volumesByInstance := make(map[string][]models.EBSVolume)
+	for _, volume := range volumes {
+		if volume.AttachedTo != "" {
+			volumesByInstance[volume.AttachedTo] = append(volumesByInstance[volume.AttachedTo], volume)
+		}
+	}
+
+	// Auto-assigned public IPs are released when an instance stops, so only Elastic IPs keep billing
+	ipsByInstance := make(map[string][]string)
+	for _, address := range addresses {
+		if address.Kind == "elastic" && address.InstanceID != "" {
+			ipsByInstance[address.InstanceID] = append(ipsByInstance[address.InstanceID], address.PublicIP)
+		}
+	}
+
+	report := &models.StoppedCostReport{
+		Resources:   make([]models.StoppedResourceCost, 0),
+		GeneratedAt: time.Now(),
+	}
+
+	for _, instance := range instances {
+		item := models.StoppedResourceCost{
+			ID:           instance.ID,
+			Name:         instance.Name,
+			ResourceType: "EC2Instance",
+			State:        instance.State,
+			VolumeIDs:    make([]string, 0),
+			ElasticIPs:   make([]string, 0),
+		}
+
+		for _, volume := range volumesByInstance[instance.ID] {
+			item.VolumeIDs = append(item.VolumeIDs, volume.ID)
+			item.StorageGB += volume.Size
+			item.StorageMonthlyCost += pricing.EBSVolumeMonthly(volume.VolumeType, volume.Size)
+		}
+
+		for _, ip := range ipsByInstance[instance.ID] {
+			item.ElasticIPs = append(item.ElasticIPs, ip)
+			item.ElasticIPMonthlyCost += pricing.PublicIPv4Monthly()
+		}
+
+		addStoppedResource(report, item)
+	}
+
+	for _, instance := range rdsInstances {
+		addStoppedResource(report, models.StoppedResourceCost{
+			ID:                 instance.ID,
+			Name:               instance.ID,
+			ResourceType:       "RDSInstance",
+			State:              instance.Status,
+			VolumeIDs:          make([]string, 0),
+			StorageGB:          instance.AllocatedStorage,
+			StorageMonthlyCost: pricing.RDSStorageMonthly(instance.StorageType, instance.AllocatedStorage),
+			ElasticIPs:         make([]string, 0),
+		})
+	}
+
+	return report, nil
+}
+
+// addStoppedResource appends a resource to the report and updates its totals
+func addStoppedResource(report *models.StoppedCostReport, item models.StoppedResourceCost) {
+	item.MonthlyCost = item.StorageMonthlyCost + item.ElasticIPMonthlyCost
+	if item.MonthlyCost == 0 {
+		return
+	}
+
+	report.Resources = append(report.Resources, item)
+	report.TotalStorageMonthlyCost += item.StorageMonthlyCost
+	report.TotalElasticIPMonthlyCost += item.ElasticIPMonthlyCost
+	report.TotalMonthlyCost += item.MonthlyCost
+}
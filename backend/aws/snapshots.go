@@ -0,0 +1,243 @@
+package aws
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+	"github.com/devesh-kumar/aws-resources-cost-board/models"
+)
+
+// DefaultSnapshotMaxAgeDays is the snapshot age after which snapshots become cleanup candidates
+const DefaultSnapshotMaxAgeDays = 90
+
+// unknownSourceVolumeID is the volume ID of snapshots copied or created from an AMI, whose
+// source volume is not recorded
+const unknownSourceVolumeID = "vol-ffffffff"
+
+// GetEBSSnapshots returns all EBS snapshots owned by the account with the AMIs that use them.
+// Costs are estimated from the source volume size, an upper bound for incremental snapshots.
+func (c *ClientsConfig) GetEBSSnapshots(ctx context.Context) ([]models.EBSSnapshot, error) {
+	volumes, err := c.GetEBSVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existingVolumes := make(map[string]bool, len(volumes))
+	for _, volume := range volumes {
+		existingVolumes[volume.ID] = true
+	}
+
+	images, err := c.getSnapshotImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var snapshots []models.EBSSnapshot
+
+	paginator := ec2.NewDescribeSnapshotsPaginator(c.EC2Client, &ec2.DescribeSnapshotsInput{
+		OwnerIds: []string{"self"},
+	})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing EBS snapshots: %v", err)
+			return nil, err
+		}
+
+		for _, snapshot := range result.Snapshots {
+			volumeID := derefString(snapshot.VolumeId)
+			size := int32(0)
+			if snapshot.VolumeSize != nil {
+				size = *snapshot.VolumeSize
+			}
+			startTime := time.Time{}
+			if snapshot.StartTime != nil {
+				startTime = *snapshot.StartTime
+			}
+			storageTier := string(snapshot.StorageTier)
+			if storageTier == "" {
+				storageTier = "standard"
+			}
+
+			snapshots = append(snapshots, models.EBSSnapshot{
+				ID:           *snapshot.SnapshotId,
+				Name:         getNameFromTags(snapshot.Tags),
+				Description:  derefString(snapshot.Description),
+				VolumeID:     volumeID,
+				VolumeSize:   size,
+				StorageTier:  storageTier,
+				State:        string(snapshot.State),
+				StartTime:    startTime,
+				AgeDays:      ageInDays(startTime, now),
+				SourceExists: existingVolumes[volumeID],
+				SourceKnown:  volumeID != "" && volumeID != unknownSourceVolumeID,
+				ImageIDs:     images[*snapshot.SnapshotId],
+				MonthlyCost:  pricing.EBSSnapshotMonthly(storageTier, size),
+			})
+		}
+	}
+
+	return snapshots, nil
+}
+
+// getSnapshotImages returns the IDs of the account's AMIs keyed by the snapshots they use
+func (c *ClientsConfig) getSnapshotImages(ctx context.Context) (map[string][]string, error) {
+	images := make(map[string][]string)
+	paginator := ec2.NewDescribeImagesPaginator(c.EC2Client, &ec2.DescribeImagesInput{
+		Owners: []string{"self"},
+	})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing AMIs: %v", err)
+			return nil, err
+		}
+
+		for _, image := range result.Images {
+			for _, mapping := range image.BlockDeviceMappings {
+				if mapping.Ebs != nil && mapping.Ebs.SnapshotId != nil {
+					images[*mapping.Ebs.SnapshotId] = append(images[*mapping.Ebs.SnapshotId], derefString(image.ImageId))
+				}
+			}
+		}
+	}
+	return images, nil
+}
+
+// GetRDSSnapshots returns all manual and automated RDS DB snapshots.
+// Automated snapshots of existing instances fall within the free backup allowance and are costed at zero.
+func (c *ClientsConfig) GetRDSSnapshots(ctx context.Context) ([]models.RDSSnapshot, error) {
+	instances, err := c.GetRDSInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existingInstances := make(map[string]bool, len(instances))
+	for _, instance := range instances {
+		existingInstances[instance.ID] = true
+	}
+
+	now := time.Now()
+	var snapshots []models.RDSSnapshot
+
+	paginator := rds.NewDescribeDBSnapshotsPaginator(c.RDSClient, &rds.DescribeDBSnapshotsInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing RDS snapshots: %v", err)
+			return nil, err
+		}
+
+		for _, snapshot := range result.DBSnapshots {
+			instanceID := derefString(snapshot.DBInstanceIdentifier)
+			snapshotType := derefString(snapshot.SnapshotType)
+			size := int32(0)
+			if snapshot.AllocatedStorage != nil {
+				size = *snapshot.AllocatedStorage
+			}
+			createdAt := time.Time{}
+			if snapshot.SnapshotCreateTime != nil {
+				createdAt = *snapshot.SnapshotCreateTime
+			}
+
+			sourceExists := existingInstances[instanceID]
+			monthlyCost := pricing.RDSSnapshotMonthly(size)
+			if snapshotType == "automated" && sourceExists {
+				monthlyCost = 0
+			}
+
+			snapshots = append(snapshots, models.RDSSnapshot{
+				ID:               *snapshot.DBSnapshotIdentifier,
+				DBInstanceID:     instanceID,
+				SnapshotType:     snapshotType,
+				Engine:           derefString(snapshot.Engine),
+				AllocatedStorage: size,
+				Status:           derefString(snapshot.Status),
+				CreationTime:     createdAt,
+				AgeDays:          ageInDays(createdAt, now),
+				SourceExists:     sourceExists,
+				MonthlyCost:      monthlyCost,
+			})
+		}
+	}
+
+	return snapshots, nil
+}
+
+// GetSnapshotReport returns snapshots whose source volume or DB instance no longer exists,
+// or that are older than maxAgeDays. Automated RDS snapshots expire through their retention
+// period, so they are only reported when orphaned. EBS snapshots used by one of the account's
+// AMIs cannot be deleted before the AMI is deregistered, so they are left out, and snapshots
+// without a recorded source volume are never reported as orphaned.
+func (c *ClientsConfig) GetSnapshotReport(ctx context.Context, maxAgeDays int) (*models.SnapshotReport, error) {
+	ebsSnapshots, err := c.GetEBSSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rdsSnapshots, err := c.GetRDSSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.SnapshotReport{
+		MaxAgeDays:  maxAgeDays,
+		Candidates:  make([]models.SnapshotCleanupCandidate, 0),
+		GeneratedAt: time.Now(),
+	}
+
+	for _, snapshot := range ebsSnapshots {
+		if len(snapshot.ImageIDs) > 0 {
+			continue
+		}
+		addSnapshotCandidate(report, models.SnapshotCleanupCandidate{
+			ID:           snapshot.ID,
+			ResourceType: "EBSSnapshot",
+			SourceID:     snapshot.VolumeID,
+			AgeDays:      snapshot.AgeDays,
+			MonthlyCost:  snapshot.MonthlyCost,
+		}, snapshot.SourceKnown && !snapshot.SourceExists, snapshot.AgeDays > maxAgeDays)
+	}
+
+	for _, snapshot := range rdsSnapshots {
+		addSnapshotCandidate(report, models.SnapshotCleanupCandidate{
+			ID:           snapshot.ID,
+			ResourceType: "RDSSnapshot",
+			SourceID:     snapshot.DBInstanceID,
+			AgeDays:      snapshot.AgeDays,
+			MonthlyCost:  snapshot.MonthlyCost,
+		}, !snapshot.SourceExists, snapshot.SnapshotType != "automated" && snapshot.AgeDays > maxAgeDays)
+	}
+
+	return report, nil
+}
+
+// addSnapshotCandidate records why a snapshot is a cleanup candidate and adds it to the report
+func addSnapshotCandidate(report *models.SnapshotReport, candidate models.SnapshotCleanupCandidate, orphaned, expired bool) {
+	if !orphaned && !expired {
+		return
+	}
+
+	if orphaned {
+		candidate.Reasons = append(candidate.Reasons, "source-deleted")
+		report.OrphanedCount++
+	}
+	if expired {
+		candidate.Reasons = append(candidate.Reasons, "older-than-policy")
+		report.ExpiredCount++
+	}
+
+	report.Candidates = append(report.Candidates, candidate)
+	report.TotalMonthlyCost += candidate.MonthlyCost
+}
+
+// ageInDays returns the number of whole days between t and now
+func ageInDays(t, now time.Time) int {
+	if t.IsZero() {
+		return 0
+	}
+	return int(now.Sub(t).Hours() / 24)
+}
@@ -0,0 +1,187 @@
+// Package client is a typed Go client for the cost board APIs. Client talks to the resource
+// server (cmd/server) and LegacyClient to the legacy server (main.go). Both follow the OpenAPI
+// documents served at /api/openapi.json.
+//
+//	c := client.New("http://localhost:8080")
+//	page, err := c.Resources(ctx, client.ListOptions{Filters: url.Values{"type": {"EC2Instance"}}, Sort: "-monthlyCost"})
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// APIError is returned for responses with an error status
+type APIError struct {
+	StatusCode int
+	Message    string
+	// Position is the offset of a query syntax or type error
+	Position int
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("cost board: %d %s", e.StatusCode, e.Message)
+}
+
+// ListOptions are the filter, search, sort and pagination parameters of list endpoints
+type ListOptions struct {
+	// Filters match fields of the listed items, such as region or tag:team, with comma-separated
+	// alternatives
+	Filters url.Values
+	Search  string
+	Sort    string
+	Limit   int
+	Cursor  string
+	// Fresh bypasses the legacy server's cache
+	Fresh bool
+}
+
+// values returns the options as query parameters
+func (o ListOptions) values() url.Values {
+	values := url.Values{}
+	for key, value := range o.Filters {
+		values[key] = value
+	}
+	if o.Search != "" {
+		values.Set("q", o.Search)
+	}
+	if o.Sort != "" {
+		values.Set("sort", o.Sort)
+	}
+	if o.Limit > 0 {
+		values.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Cursor != "" {
+		values.Set("cursor", o.Cursor)
+	}
+	if o.Fresh {
+		values.Set("fresh", "true")
+	}
+	return values
+}
+
+// Page is one page of a list
+type Page[T any] struct {
+	Items []T
+	// Total is the number of matching items before pagination
+	Total int
+	// NextCursor is passed as ListOptions.Cursor for the next page; empty on the last page
+	NextCursor string
+	// TotalMonthlyCost is the combined monthly cost of the matching items
+	TotalMonthlyCost float64
+}
+
+// transport sends requests to a server's /api routes
+type transport struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newTransport(baseURL string, httpClient *http.Client) transport {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return transport{baseURL: strings.TrimSuffix(baseURL, "/") + "/api", httpClient: httpClient}
+}
+
+// do sends a request and returns the response, or an APIError for an error status. The caller
+// closes the body.
+func (t transport) do(ctx context.Context, method, path string, query url.Values, contentType string, body io.Reader) (*http.Response, error) {
+	target := t.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: resp.Status}
+		var payload struct {
+			Error    string `json:"error"`
+			Position int    `json:"position"`
+		}
+		if data, _ := io.ReadAll(resp.Body); json.Unmarshal(data, &payload) == nil && payload.Error != "" {
+			apiErr.Message, apiErr.Position = payload.Error, payload.Position
+		}
+		return nil, apiErr
+	}
+	return resp, nil
+}
+
+// getJSON decodes the JSON response of a GET request into out
+func (t transport) getJSON(ctx context.Context, path string, query url.Values, out any) error {
+	return t.sendJSON(ctx, http.MethodGet, path, query, nil, out)
+}
+
+// sendJSON sends in as a JSON body, when not nil, and decodes the response into out, when not nil
+func (t transport) sendJSON(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	var body io.Reader
+	contentType := ""
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body, contentType = bytes.NewReader(data), "application/json"
+	}
+
+	resp, err := t.do(ctx, method, path, query, contentType, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// download returns the body of a file response. The caller closes it.
+func (t transport) download(ctx context.Context, path string, query url.Values) (io.ReadCloser, error) {
+	resp, err := t.do(ctx, http.MethodGet, path, query, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// list fetches one page of a list endpoint
+func list[T any](ctx context.Context, t transport, path string, query url.Values) (Page[T], error) {
+	resp, err := t.do(ctx, http.MethodGet, path, query, "", nil)
+	if err != nil {
+		return Page[T]{}, err
+	}
+	defer resp.Body.Close()
+
+	page := Page[T]{NextCursor: resp.Header.Get("X-Next-Cursor")}
+	if err := json.NewDecoder(resp.Body).Decode(&page.Items); err != nil {
+		return Page[T]{}, err
+	}
+	page.Total, _ = strconv.Atoi(resp.Header.Get("X-Total-Count"))
+	page.TotalMonthlyCost, _ = strconv.ParseFloat(resp.Header.Get("X-Total-Monthly-Cost"), 64)
+	return page, nil
+}
+
+// OpenAPI returns the server's OpenAPI document
+func (t transport) OpenAPI(ctx context.Context) (json.RawMessage, error) {
+	var doc json.RawMessage
+	err := t.getJSON(ctx, "/openapi.json", nil, &doc)
+	return doc, err
+}
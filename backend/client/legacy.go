@@ -0,0 +1,215 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// LegacyClient calls the legacy server (main.go)
+type LegacyClient struct {
+	transport
+}
+
+// NewLegacy creates a client for the legacy server at baseURL. An optional httpClient replaces
+// http.DefaultClient.
+func NewLegacy(baseURL string, httpClient ...*http.Client) *LegacyClient {
+	return &LegacyClient{transport: newTransport(baseURL, firstClient(httpClient))}
+}
+
+// CostOptions select the period of cost endpoints. Empty dates mean the last 30 days.
+type CostOptions struct {
+	Start string
+	End   string
+	Fresh bool
+}
+
+// values returns the options as query parameters
+func (o CostOptions) values() url.Values {
+	values := url.Values{}
+	if o.Start != "" && o.End != "" {
+		values.Set("start", o.Start)
+		values.Set("end", o.End)
+	}
+	if o.Fresh {
+		values.Set("fresh", "true")
+	}
+	return values
+}
+
+// InventoryPage is one page of the resource inventory
+type InventoryPage struct {
+	ResourceInventory
+	// Total is the number of matching items across the lists before pagination
+	Total int
+	// NextCursor is passed as ListOptions.Cursor for the next page; empty on the last page
+	NextCursor string
+	// TotalMonthlyCost is the combined monthly cost of the matching items
+	TotalMonthlyCost float64
+}
+
+// Resources returns EC2 instances, RDS instances, EBS volumes and log groups. Filters apply to
+// every list and ListOptions.Limit to each of them; ?state= selects EC2 and RDS states.
+func (c *LegacyClient) Resources(ctx context.Context, opts ListOptions) (InventoryPage, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/resources", opts.values(), "", nil)
+	if err != nil {
+		return InventoryPage{}, err
+	}
+	defer resp.Body.Close()
+
+	page := InventoryPage{NextCursor: resp.Header.Get("X-Next-Cursor")}
+	if err := json.NewDecoder(resp.Body).Decode(&page.ResourceInventory); err != nil {
+		return InventoryPage{}, err
+	}
+	page.Total, _ = strconv.Atoi(resp.Header.Get("X-Total-Count"))
+	page.TotalMonthlyCost, _ = strconv.ParseFloat(resp.Header.Get("X-Total-Monthly-Cost"), 64)
+	return page, nil
+}
+
+// Summary returns a summary of resources and their costs
+func (c *LegacyClient) Summary(ctx context.Context, fresh bool) (ResourcesSummary, error) {
+	var summary ResourcesSummary
+	err := c.getJSON(ctx, "/summary", ListOptions{Fresh: fresh}.values(), &summary)
+	return summary, err
+}
+
+// EC2Instances lists EC2 instances, running ones unless a state filter is given
+func (c *LegacyClient) EC2Instances(ctx context.Context, opts ListOptions) (Page[EC2Instance], error) {
+	return list[EC2Instance](ctx, c.transport, "/ec2", opts.values())
+}
+
+// RDSInstances lists RDS instances, available ones unless a state filter is given
+func (c *LegacyClient) RDSInstances(ctx context.Context, opts ListOptions) (Page[RDSInstance], error) {
+	return list[RDSInstance](ctx, c.transport, "/rds", opts.values())
+}
+
+// EBSVolumes lists EBS volumes
+func (c *LegacyClient) EBSVolumes(ctx context.Context, opts ListOptions) (Page[EBSVolume], error) {
+	return list[EBSVolume](ctx, c.transport, "/ebs", opts.values())
+}
+
+// LogGroups lists CloudWatch log groups
+func (c *LegacyClient) LogGroups(ctx context.Context, opts ListOptions) (Page[CloudWatchLogGroup], error) {
+	return list[CloudWatchLogGroup](ctx, c.transport, "/cloudwatch/log-groups", opts.values())
+}
+
+// EBSSnapshots lists EBS snapshots
+func (c *LegacyClient) EBSSnapshots(ctx context.Context, opts ListOptions) (Page[EBSSnapshot], error) {
+	return list[EBSSnapshot](ctx, c.transport, "/snapshots/ebs", opts.values())
+}
+
+// RDSSnapshots lists RDS snapshots
+func (c *LegacyClient) RDSSnapshots(ctx context.Context, opts ListOptions) (Page[RDSSnapshot], error) {
+	return list[RDSSnapshot](ctx, c.transport, "/snapshots/rds", opts.values())
+}
+
+// PublicIPv4Addresses returns billed public IPv4 addresses and their charges
+func (c *LegacyClient) PublicIPv4Addresses(ctx context.Context, opts ListOptions) (PublicIPv4Report, error) {
+	var report PublicIPv4Report
+	err := c.getJSON(ctx, "/public-ips", opts.values(), &report)
+	return report, err
+}
+
+// Cost returns daily cost by service. includeImported adds spend from imported FOCUS files.
+func (c *LegacyClient) Cost(ctx context.Context, opts CostOptions, includeImported bool) (CostData, error) {
+	values := opts.values()
+	if includeImported {
+		values.Set("include", "imported")
+	}
+	var data CostData
+	err := c.getJSON(ctx, "/cost", values, &data)
+	return data, err
+}
+
+// DataTransferCost returns data transfer cost by category, with a DAILY or MONTHLY trend
+func (c *LegacyClient) DataTransferCost(ctx context.Context, opts CostOptions, granularity string) (DataTransferReport, error) {
+	values := opts.values()
+	if granularity != "" {
+		values.Set("granularity", granularity)
+	}
+	var report DataTransferReport
+	err := c.getJSON(ctx, "/cost/data-transfer", values, &report)
+	return report, err
+}
+
+// StoppedCostReport returns stopped instances that still cost money
+func (c *LegacyClient) StoppedCostReport(ctx context.Context) (StoppedCostReport, error) {
+	var report StoppedCostReport
+	err := c.getJSON(ctx, "/reports/stopped-costs", nil, &report)
+	return report, err
+}
+
+// SnapshotReport returns orphaned snapshots and snapshots older than maxAgeDays; zero uses the
+// server's default of 90 days
+func (c *LegacyClient) SnapshotReport(ctx context.Context, maxAgeDays int) (SnapshotReport, error) {
+	values := url.Values{}
+	if maxAgeDays > 0 {
+		values.Set("maxAgeDays", strconv.Itoa(maxAgeDays))
+	}
+	var report SnapshotReport
+	err := c.getJSON(ctx, "/reports/snapshots", values, &report)
+	return report, err
+}
+
+// Export downloads a dataset, such as "ec2" or "cost", as format ("csv", "xlsx" or "ndjson").
+// params can add columns, dates and list filters. The caller closes the file.
+func (c *LegacyClient) Export(ctx context.Context, dataset, format string, params url.Values) (io.ReadCloser, error) {
+	values := url.Values{}
+	for key, value := range params {
+		values[key] = value
+	}
+	values.Set("dataset", dataset)
+	values.Set("format", format)
+	return c.download(ctx, "/export", values)
+}
+
+// FOCUSExport downloads cost data ("cost", "imported" or "all") as a FOCUS file in format
+// ("csv" or "parquet"). The caller closes the file.
+func (c *LegacyClient) FOCUSExport(ctx context.Context, dataset, format string, opts CostOptions) (io.ReadCloser, error) {
+	values := opts.values()
+	values.Set("dataset", dataset)
+	values.Set("format", format)
+	return c.download(ctx, "/focus/export", values)
+}
+
+// ImportFOCUS uploads a FOCUS file. name gives its format: .csv, .csv.gz or .parquet.
+func (c *LegacyClient) ImportFOCUS(ctx context.Context, name string, file io.Reader) (FOCUSDataset, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", name)
+	if err != nil {
+		return FOCUSDataset{}, err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return FOCUSDataset{}, err
+	}
+	if err := form.Close(); err != nil {
+		return FOCUSDataset{}, err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, "/focus/import", nil, form.FormDataContentType(), &body)
+	if err != nil {
+		return FOCUSDataset{}, err
+	}
+	defer resp.Body.Close()
+	var dataset FOCUSDataset
+	err = json.NewDecoder(resp.Body).Decode(&dataset)
+	return dataset, err
+}
+
+// FOCUSImports lists imported FOCUS files
+func (c *LegacyClient) FOCUSImports(ctx context.Context) ([]FOCUSDataset, error) {
+	var datasets []FOCUSDataset
+	err := c.getJSON(ctx, "/focus/imports", nil, &datasets)
+	return datasets, err
+}
+
+// DeleteFOCUSImport deletes an imported FOCUS file
+func (c *LegacyClient) DeleteFOCUSImport(ctx context.Context, id string) error {
+	return c.sendJSON(ctx, http.MethodDelete, "/focus/imports/"+url.PathEscape(id), nil, nil, nil)
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client calls the resource server (cmd/server)
+type Client struct {
+	transport
+}
+
+// New creates a client for the resource server at baseURL, such as http://localhost:8080.
+// An optional httpClient replaces http.DefaultClient.
+func New(baseURL string, httpClient ...*http.Client) *Client {
+	return &Client{transport: newTransport(baseURL, firstClient(httpClient))}
+}
+
+// firstClient returns the optional HTTP client argument
+func firstClient(clients []*http.Client) *http.Client {
+	if len(clients) > 0 {
+		return clients[0]
+	}
+	return nil
+}
+
+// Resources lists inventoried resources
+func (c *Client) Resources(ctx context.Context, opts ListOptions) (Page[Resource], error) {
+	return list[Resource](ctx, c.transport, "/resources", opts.values())
+}
+
+// CostSummary returns the cost summary of the latest refresh
+func (c *Client) CostSummary(ctx context.Context) (CostSummary, error) {
+	var summary CostSummary
+	err := c.getJSON(ctx, "/cost-summary", nil, &summary)
+	return summary, err
+}
+
+// Refresh starts a refresh, or returns the one already running
+func (c *Client) Refresh(ctx context.Context) (RefreshStarted, error) {
+	var started RefreshStarted
+	err := c.sendJSON(ctx, http.MethodPost, "/refresh", nil, nil, &started)
+	return started, err
+}
+
+// Jobs lists recent refresh jobs
+func (c *Client) Jobs(ctx context.Context) ([]RefreshJob, error) {
+	var jobs []RefreshJob
+	err := c.getJSON(ctx, "/jobs", nil, &jobs)
+	return jobs, err
+}
+
+// Job returns a refresh job
+func (c *Client) Job(ctx context.Context, id string) (RefreshJob, error) {
+	var job RefreshJob
+	err := c.getJSON(ctx, "/jobs/"+url.PathEscape(id), nil, &job)
+	return job, err
+}
+
+// ComputeGroups lists EKS node groups and ECS clusters with their instances
+func (c *Client) ComputeGroups(ctx context.Context) ([]ComputeGroup, error) {
+	var groups []ComputeGroup
+	err := c.getJSON(ctx, "/compute-groups", nil, &groups)
+	return groups, err
+}
+
+// NetworkSummary returns hourly-billed VPC networking by availability zone
+func (c *Client) NetworkSummary(ctx context.Context) (NetworkCostSummary, error) {
+	var summary NetworkCostSummary
+	err := c.getJSON(ctx, "/network-summary", nil, &summary)
+	return summary, err
+}
+
+// Collectors lists the registered collectors
+func (c *Client) Collectors(ctx context.Context) ([]CollectorInfo, error) {
+	var collectors []CollectorInfo
+	err := c.getJSON(ctx, "/collectors", nil, &collectors)
+	return collectors, err
+}
+
+// Recommendations lists one recommendation per flag raised on a resource
+func (c *Client) Recommendations(ctx context.Context, opts ListOptions) (Page[Recommendation], error) {
+	return list[Recommendation](ctx, c.transport, "/recommendations", opts.values())
+}
+
+// Query lists the resources matching a resource query. Syntax and type errors are returned as an
+// APIError with the position of the error.
+func (c *Client) Query(ctx context.Context, query string, opts ListOptions) (Page[Resource], error) {
+	values := opts.values()
+	values.Set("query", query)
+	return list[Resource](ctx, c.transport, "/query", values)
+}
+
+// SavedQueries lists saved queries
+func (c *Client) SavedQueries(ctx context.Context) ([]SavedQuery, error) {
+	var queries []SavedQuery
+	err := c.getJSON(ctx, "/queries", nil, &queries)
+	return queries, err
+}
+
+// SavedQuery returns a saved query
+func (c *Client) SavedQuery(ctx context.Context, name string) (SavedQuery, error) {
+	var query SavedQuery
+	err := c.getJSON(ctx, "/queries/"+url.PathEscape(name), nil, &query)
+	return query, err
+}
+
+// SaveQuery creates or replaces a saved query
+func (c *Client) SaveQuery(ctx context.Context, query SavedQuery) (SavedQuery, error) {
+	var saved SavedQuery
+	err := c.sendJSON(ctx, http.MethodPost, "/queries", nil, query, &saved)
+	return saved, err
+}
+
+// DeleteSavedQuery deletes a saved query
+func (c *Client) DeleteSavedQuery(ctx context.Context, name string) error {
+	return c.sendJSON(ctx, http.MethodDelete, "/queries/"+url.PathEscape(name), nil, nil, nil)
+}
+
+// SavedQueryResults lists the resources matching a saved query
+func (c *Client) SavedQueryResults(ctx context.Context, name string, opts ListOptions) (Page[Resource], error) {
+	return list[Resource](ctx, c.transport, "/queries/"+url.PathEscape(name)+"/results", opts.values())
+}
+
+// CURStatus returns the CUR source and the files ingested from it
+func (c *Client) CURStatus(ctx context.Context) (CURStatus, error) {
+	var status CURStatus
+	err := c.getJSON(ctx, "/cur", nil, &status)
+	return status, err
+}
+
+// SyncCUR ingests new and changed CUR files now
+func (c *Client) SyncCUR(ctx context.Context) (CURStatus, error) {
+	var status CURStatus
+	err := c.sendJSON(ctx, http.MethodPost, "/cur/sync", nil, nil, &status)
+	return status, err
+}
+
+// ResourceCost returns the cost of a resource in [start, end) from CUR line items, per hour or
+// day (granularity "hourly" or "daily")
+func (c *Client) ResourceCost(ctx context.Context, resourceID string, start, end time.Time, granularity string) (ResourceCost, error) {
+	values := url.Values{
+		"resourceId": {resourceID},
+		"start":      {start.Format(time.RFC3339)},
+		"end":        {end.Format(time.RFC3339)},
+	}
+	if granularity != "" {
+		values.Set("granularity", granularity)
+	}
+	var cost ResourceCost
+	err := c.getJSON(ctx, "/cur/cost", values, &cost)
+	return cost, err
+}
+
+// Export downloads a dataset ("resources" or "recommendations") as format ("csv", "xlsx" or
+// "ndjson"). params can add columns, query and list filters. The caller closes the file.
+func (c *Client) Export(ctx context.Context, dataset, format string, params url.Values) (io.ReadCloser, error) {
+	values := url.Values{}
+	for key, value := range params {
+		values[key] = value
+	}
+	values.Set("dataset", dataset)
+	values.Set("format", format)
+	return c.download(ctx, "/export", values)
+}
+
+// FOCUSExport downloads estimated resource costs as a FOCUS file in format ("csv" or "parquet").
+// The caller closes the file.
+func (c *Client) FOCUSExport(ctx context.Context, format string) (io.ReadCloser, error) {
+	return c.download(ctx, "/focus/export", url.Values{"format": {format}})
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/focus"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	legacy "github.com/devesh-kumar/aws-resources-cost-board/models"
+)
+
+// Models of the resource server
+type (
+	Resource           = models.Resource
+	ResourceType       = models.ResourceType
+	Tag                = models.Tag
+	Flag               = models.Flag
+	CostSummary        = models.CostSummary
+	ServiceCost        = models.ServiceCost
+	RefreshJob         = models.RefreshJob
+	RefreshStarted     = models.RefreshStarted
+	CollectorRun       = models.CollectorRun
+	CollectorInfo      = models.CollectorInfo
+	JobStatus          = models.JobStatus
+	ComputeGroup       = models.ComputeGroup
+	NetworkCostSummary = models.NetworkCostSummary
+	Recommendation     = models.Recommendation
+	SavedQuery         = models.SavedQuery
+	CURStatus          = models.CURStatus
+	CURFile            = models.CURFile
+	ResourceCost       = models.ResourceCost
+	CostPoint          = models.CostPoint
+)
+
+// Models of the legacy server
+type (
+	EC2Instance              = legacy.EC2Instance
+	RDSInstance              = legacy.RDSInstance
+	EBSVolume                = legacy.EBSVolume
+	CloudWatchLogGroup       = legacy.CloudWatchLogGroup
+	PublicIPv4Address        = legacy.PublicIPv4Address
+	PublicIPv4Charges        = legacy.PublicIPv4Charges
+	PublicIPv4Report         = legacy.PublicIPv4Report
+	ResourceInventory        = legacy.ResourceInventory
+	ResourcesSummary         = legacy.ResourcesSummary
+	CostData                 = legacy.CostData
+	CostByService            = legacy.CostByService
+	DataTransferReport       = legacy.DataTransferReport
+	DataTransferUsage        = legacy.DataTransferUsage
+	DataTransferTrendPoint   = legacy.DataTransferTrendPoint
+	StoppedCostReport        = legacy.StoppedCostReport
+	StoppedResourceCost      = legacy.StoppedResourceCost
+	EBSSnapshot              = legacy.EBSSnapshot
+	RDSSnapshot              = legacy.RDSSnapshot
+	SnapshotReport           = legacy.SnapshotReport
+	SnapshotCleanupCandidate = legacy.SnapshotCleanupCandidate
+	FOCUSDataset             = focus.Dataset
+)
@@ -0,0 +1,117 @@
+// Command openapi prints the OpenAPI document of a cost board server, or checks the responses of
+// a running server against it.
+//
+//	openapi > openapi.json                      # resource server (cmd/server)
+//	openapi -api legacy > openapi-legacy.json   # legacy server (main.go)
+//	openapi -check http://localhost:8080        # validate GET responses of a running server
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	legacyapi "github.com/devesh-kumar/aws-resources-cost-board/api"
+	resourceapi "github.com/devesh-kumar/aws-resources-cost-board/internal/api"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/openapi"
+)
+
+func main() {
+	apiName := flag.String("api", "resource", "document to use: resource or legacy")
+	check := flag.String("check", "", "base URL of a running server whose responses to check")
+	flag.Parse()
+
+	var doc *openapi.Document
+	switch *apiName {
+	case "resource":
+		doc = resourceapi.Spec()
+	case "legacy":
+		doc = legacyapi.Spec()
+	default:
+		fmt.Fprintf(os.Stderr, "openapi: -api must be resource or legacy\n")
+		os.Exit(2)
+	}
+
+	if *check == "" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(doc); err != nil {
+			fmt.Fprintf(os.Stderr, "openapi: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if failures := checkServer(doc, strings.TrimSuffix(*check, "/")); failures > 0 {
+		fmt.Fprintf(os.Stderr, "openapi: %d responses do not match the document\n", failures)
+		os.Exit(1)
+	}
+}
+
+// checkServer requests every GET operation that needs no parameters and returns JSON, validating
+// each response, and returns the number of failures
+func checkServer(doc *openapi.Document, baseURL string) int {
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	failures := 0
+	for _, path := range paths {
+		op := (*doc.Paths[path])["get"]
+		if op == nil || !checkable(op) {
+			continue
+		}
+
+		url := baseURL + doc.Servers[0].URL + path
+		status, body, err := get(url)
+		if err == nil {
+			err = doc.ValidateResponse("GET", path, status, body)
+		}
+		if err == nil && status >= 400 {
+			err = fmt.Errorf("status %d: %s", status, body)
+		}
+		if err != nil {
+			failures++
+			fmt.Printf("FAIL GET %s: %v\n", path, err)
+			continue
+		}
+		fmt.Printf("ok   GET %s\n", path)
+	}
+	return failures
+}
+
+// checkable reports whether an operation can be requested without parameters and returns JSON
+func checkable(op *openapi.Operation) bool {
+	for _, param := range op.Parameters {
+		if param.Required {
+			return false
+		}
+	}
+	for status, response := range op.Responses {
+		if status == "default" {
+			continue
+		}
+		if _, ok := response.Content["application/json"]; !ok || status != "200" {
+			return false
+		}
+	}
+	return true
+}
+
+// get fetches a URL and returns the status and body
+func get(url string) (int, []byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, body, err
+}
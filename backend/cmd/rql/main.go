@@ -0,0 +1,142 @@
+// Command rql runs resource queries against a running server or a saved /api/resources dump.
+//
+//	rql 'type = "EFSFileSystem" AND sizeBytes > 100e9 AND tag.env != "prod"'
+//	rql -file resources.json 'flag = "unused-secret"'
+//	rql -check 'monthlyCost > 100 OR'
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/rql"
+)
+
+func main() {
+	server := flag.String("server", envOr("COST_BOARD_URL", "http://localhost:8080"), "cost board server URL")
+	file := flag.String("file", "", "evaluate against a JSON array of resources instead of the server")
+	checkOnly := flag.Bool("check", false, "only parse and type-check the query")
+	sortBy := flag.String("sort", "-monthlyCost", "sort order passed to the server, e.g. name or -monthlyCost")
+	asJSON := flag.Bool("json", false, "print matching resources as JSON")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: rql [flags] QUERY\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	src := flag.Arg(0)
+
+	// Check locally first so errors point at the query without a round trip
+	query, err := rql.Compile(src)
+	if err != nil {
+		fail(src, err)
+	}
+	if *checkOnly {
+		fmt.Println(query.Expr())
+		return
+	}
+
+	var resources []models.Resource
+	if *file != "" {
+		resources, err = readResources(*file)
+		if err == nil {
+			resources = query.Filter(resources)
+		}
+	} else {
+		resources, err = fetchResources(*server, src, *sortBy)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rql: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(resources)
+		return
+	}
+	printTable(resources)
+}
+
+// readResources reads a JSON array of resources, as returned by /api/resources
+func readResources(path string) ([]models.Resource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var resources []models.Resource
+	if err := json.Unmarshal(data, &resources); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return resources, nil
+}
+
+// fetchResources runs the query on the server
+func fetchResources(server, src, sortBy string) ([]models.Resource, error) {
+	params := url.Values{"query": {src}}
+	if sortBy != "" {
+		params.Set("sort", sortBy)
+	}
+	resp, err := http.Get(strings.TrimRight(server, "/") + "/api/query?" + params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return nil, fmt.Errorf("server returned %s: %s", resp.Status, body.Error)
+	}
+
+	var resources []models.Resource
+	if err := json.NewDecoder(resp.Body).Decode(&resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// printTable prints the matching resources and their combined monthly cost
+func printTable(resources []models.Resource) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTYPE\tNAME\tREGION\tSTATUS\tMONTHLY COST")
+	total := 0.0
+	for _, r := range resources {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t$%.2f\n", r.ID, r.Type, r.Name, r.Region, r.Status, r.MonthlyCost)
+		total += r.MonthlyCost
+	}
+	w.Flush()
+	fmt.Printf("\n%d resources, $%.2f per month\n", len(resources), total)
+}
+
+// fail prints a query error with a caret under its position and exits
+func fail(src string, err error) {
+	var queryErr *rql.Error
+	if errors.As(err, &queryErr) {
+		fmt.Fprintf(os.Stderr, "%s\n%s^\n", src, strings.Repeat(" ", queryErr.Pos))
+	}
+	fmt.Fprintf(os.Stderr, "rql: %v\n", err)
+	os.Exit(1)
+}
+
+// envOr returns an environment variable or a fallback
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
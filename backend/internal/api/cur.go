@@ -0,0 +1,75 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/services"
+)
+
+// GetCURStatus handles GET /api/cur
+func (s *Server) GetCURStatus(c *gin.Context) {
+	c.JSON(200, s.resourceService.GetCURStatus())
+}
+
+// SyncCUR handles POST /api/cur/sync, ingesting new and changed CUR files now rather than on
+// the next refresh
+func (s *Server) SyncCUR(c *gin.Context) {
+	status, err := s.resourceService.SyncCUR(c.Request.Context())
+	if errors.Is(err, services.ErrCURDisabled) {
+		c.JSON(404, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(502, gin.H{"error": err.Error(), "status": status})
+		return
+	}
+	c.JSON(200, status)
+}
+
+// GetResourceCost handles GET /api/cur/cost?resourceId=&start=&end=&granularity=hourly|daily.
+// The period defaults to the last seven days and accepts dates or RFC 3339 timestamps.
+func (s *Server) GetResourceCost(c *gin.Context) {
+	id := c.Query("resourceId")
+	if id == "" {
+		c.JSON(400, gin.H{"error": "resourceId is required"})
+		return
+	}
+
+	end := time.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+	start := end.AddDate(0, 0, -7)
+	var err error
+	if value := c.Query("start"); value != "" {
+		if start, err = parseTimeParam(value); err != nil {
+			c.JSON(400, gin.H{"error": "start: " + err.Error()})
+			return
+		}
+	}
+	if value := c.Query("end"); value != "" {
+		if end, err = parseTimeParam(value); err != nil {
+			c.JSON(400, gin.H{"error": "end: " + err.Error()})
+			return
+		}
+	}
+
+	cost, err := s.resourceService.GetResourceCost(id, start, end, c.Query("granularity"))
+	if errors.Is(err, services.ErrCURDisabled) {
+		c.JSON(404, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, cost)
+}
+
+// parseTimeParam parses a YYYY-MM-DD date or an RFC 3339 timestamp
+func parseTimeParam(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/events"
+)
+
+// eventsHeartbeat is how often an idle event stream sends a keep-alive
+const eventsHeartbeat = 15 * time.Second
+
+// StreamEvents handles GET /api/events as a Server-Sent Events stream. Clients reconnecting with
+// a Last-Event-ID header receive the recent events they missed. ?types= limits the event types.
+func (s *Server) StreamEvents(c *gin.Context) {
+	lastID, _ := strconv.ParseInt(c.GetHeader("Last-Event-ID"), 10, 64)
+	wanted := parseEventTypes(c)
+
+	ch, unsubscribe := s.resourceService.Events().Subscribe(lastID)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case event, ok := <-ch:
+			if !ok {
+				return
+			}
+			if !wanted(event.Type) {
+				continue
+			}
+			data, err := json.Marshal(event.Data)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// StreamEventsWebSocket handles GET /api/events/ws, sending each event as a JSON message.
+// ?lastEventId= resumes after a known event and ?types= limits the event types.
+func (s *Server) StreamEventsWebSocket(c *gin.Context) {
+	lastID, _ := strconv.ParseInt(c.Query("lastEventId"), 10, 64)
+	wanted := parseEventTypes(c)
+
+	server := websocket.Server{
+		Handshake: s.checkWebSocketOrigin,
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+
+			ch, unsubscribe := s.resourceService.Events().Subscribe(lastID)
+			defer unsubscribe()
+
+			// Clients do not send messages; reading detects when they disconnect
+			closed := make(chan struct{})
+			go func() {
+				io.Copy(io.Discard, ws)
+				close(closed)
+			}()
+
+			for {
+				select {
+				case <-closed:
+					return
+				case event, ok := <-ch:
+					if !ok {
+						return
+					}
+					if !wanted(event.Type) {
+						continue
+					}
+					if err := websocket.JSON.Send(ws, event); err != nil {
+						return
+					}
+				}
+			}
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// checkWebSocketOrigin accepts WebSocket connections from the origins allowed by CORS
+func (s *Server) checkWebSocketOrigin(config *websocket.Config, req *http.Request) error {
+	origin := req.Header.Get("Origin")
+	if s.config.CorsAllowed == "*" || s.config.CorsAllowed == origin {
+		return nil
+	}
+	return fmt.Errorf("origin %q is not allowed", origin)
+}
+
+// parseEventTypes reads the comma-separated ?types= query parameter. Without it every type is
+// wanted; resync events are always wanted.
+func parseEventTypes(c *gin.Context) func(string) bool {
+	raw := c.Query("types")
+	if raw == "" {
+		return func(string) bool { return true }
+	}
+
+	types := make(map[string]bool)
+	for _, t := range strings.Split(raw, ",") {
+		types[strings.TrimSpace(t)] = true
+	}
+	return func(eventType string) bool { return types[eventType] || eventType == events.Resync }
+}
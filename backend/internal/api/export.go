@@ -0,0 +1,50 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/export"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/listing"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/rql"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/services"
+)
+
+// GetRecommendations handles GET /api/recommendations, applying the list query
+func (s *Server) GetRecommendations(c *gin.Context) {
+	listing.Respond(c, s.resourceService.GetRecommendations())
+}
+
+// ExportData handles GET /api/export, streaming ?dataset=resources (the default) or
+// recommendations as CSV, XLSX or NDJSON. Rows can be narrowed with list filters such as
+// ?type= and ?tag:team=, or with a resource ?query=.
+func (s *Server) ExportData(c *gin.Context) {
+	resources := s.resourceService.GetAllResources()
+	if src := c.Query("query"); src != "" {
+		query, err := rql.Compile(src)
+		if err != nil {
+			queryError(c, err)
+			return
+		}
+		resources = query.Filter(resources)
+	}
+
+	skip := append([]string{"query"}, export.Params...)
+	switch dataset := c.DefaultQuery("dataset", "resources"); dataset {
+	case "resources":
+		exportList(c, dataset, resources, skip)
+	case "recommendations":
+		exportList(c, dataset, services.BuildRecommendations(resources), skip)
+	default:
+		c.JSON(400, gin.H{"error": "dataset must be resources or recommendations"})
+	}
+}
+
+// exportList filters records with the list query and streams them
+func exportList[T any](c *gin.Context, dataset string, records []T, skip []string) {
+	records, err := listing.FilterQuery(c, records, skip...)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	export.Respond(c, dataset, records)
+}
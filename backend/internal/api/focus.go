@@ -0,0 +1,32 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/focus"
+)
+
+// ExportFOCUS handles GET /api/focus/export, streaming the estimated monthly cost of every
+// inventoried resource as a FOCUS CSV or Parquet file (?format=)
+func (s *Server) ExportFOCUS(c *gin.Context) {
+	format, err := focus.ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	rows := focus.FromResources(s.resourceService.GetAllResources(), now)
+
+	filename := fmt.Sprintf("focus-resources-%s.%s", now.Format("20060102"), format)
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(200)
+	if err := focus.Write(c.Writer, format, rows); err != nil {
+		log.Printf("Error exporting FOCUS data: %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/metrics"
+)
+
+// GetMetrics handles GET /metrics, exposing the latest refresh in the Prometheus text format
+func (s *Server) GetMetrics(c *gin.Context) {
+	c.Header("Content-Type", metrics.ContentType)
+	c.Status(200)
+	if err := metrics.Write(c.Writer, s.resourceService.Metrics()); err != nil {
+		log.Printf("Error writing metrics: %v", err)
+	}
+}
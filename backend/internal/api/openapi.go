@@ -0,0 +1,112 @@
+package api
+
+import (
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/events"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/openapi"
+)
+
+// exportParams are the query parameters of export endpoints
+var exportParams = []openapi.Param{
+	{Name: "format", Enum: []string{"csv", "xlsx", "ndjson"}, Description: "File format, csv by default"},
+	{Name: "columns", Description: "Comma-separated columns to export, in order"},
+	{Name: "query", Description: "Resource query narrowing the rows"},
+}
+
+// routes describes every endpoint of the resource server for the OpenAPI document. Keep it in
+// step with setupRoutes; routes missing here are logged at startup.
+var routes = []openapi.Route{
+	{Method: "GET", Path: "/resources", ID: "listResources", Tag: "resources", List: true,
+		Summary: "List inventoried resources", Response: []models.Resource{}},
+	{Method: "GET", Path: "/cost-summary", ID: "getCostSummary", Tag: "costs",
+		Summary: "Get the cost summary of the latest refresh", Response: models.CostSummary{}},
+	{Method: "POST", Path: "/refresh", ID: "startRefresh", Tag: "jobs", Status: 202,
+		Summary: "Start a refresh, or return the one already running", Response: models.RefreshStarted{}},
+	{Method: "GET", Path: "/jobs", ID: "listJobs", Tag: "jobs",
+		Summary: "List recent refresh jobs", Response: []models.RefreshJob{}},
+	{Method: "GET", Path: "/jobs/:id", ID: "getJob", Tag: "jobs",
+		Summary: "Get a refresh job", Response: models.RefreshJob{}},
+	{Method: "GET", Path: "/history", ID: "listSnapshots", Tag: "jobs",
+		Summary:  "List the inventory count and cost left by recent refreshes, per collector",
+		Query:    []openapi.Param{{Name: "collector", Description: "Collector name narrowing each snapshot"}},
+		Response: []models.InventorySnapshot{}},
+	{Method: "GET", Path: "/history/:id", ID: "getSnapshot", Tag: "jobs",
+		Summary: "Get the inventory left by a refresh job, with each resource's cost", Response: models.InventorySnapshot{}},
+	{Method: "GET", Path: "/compute-groups", ID: "listComputeGroups", Tag: "resources",
+		Summary: "List EKS node groups and ECS clusters with their instances", Response: []models.ComputeGroup{}},
+	{Method: "GET", Path: "/network-summary", ID: "getNetworkSummary", Tag: "resources",
+		Summary: "Get hourly-billed VPC networking by availability zone", Response: models.NetworkCostSummary{}},
+	{Method: "GET", Path: "/collectors", ID: "listCollectors", Tag: "resources",
+		Summary: "List the registered collectors", Response: []models.CollectorInfo{}},
+	{Method: "GET", Path: "/events", ID: "streamEvents", Tag: "events",
+		Summary: "Stream refresh, alert and resource change events as Server-Sent Events",
+		Query:   []openapi.Param{{Name: "types", Description: "Comma-separated event types to receive"}},
+		Content: []string{"text/event-stream"}},
+	{Method: "GET", Path: "/events/ws", ID: "streamEventsWebSocket", Tag: "events", Status: 101,
+		Summary: "Stream events as JSON messages over a WebSocket",
+		Query: []openapi.Param{
+			{Name: "types", Description: "Comma-separated event types to receive"},
+			{Name: "lastEventId", Type: "integer", Description: "ID of the last event received, to resume"},
+		},
+		Response: events.Event{}},
+	{Method: "GET", Path: "/recommendations", ID: "listRecommendations", Tag: "resources", List: true,
+		Summary: "List one recommendation per flag raised on a resource", Response: []models.Recommendation{}},
+	{Method: "GET", Path: "/export", ID: "exportData", Tag: "exports",
+		Summary: "Download resources or recommendations as CSV, XLSX or JSON Lines",
+		Query: append([]openapi.Param{
+			{Name: "dataset", Enum: []string{"resources", "recommendations"}, Description: "Dataset to export, resources by default"},
+		}, exportParams...),
+		Content: []string{"text/csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/x-ndjson"}},
+	{Method: "GET", Path: "/focus/export", ID: "exportFOCUS", Tag: "exports",
+		Summary: "Download estimated resource costs as a FOCUS file",
+		Query:   []openapi.Param{{Name: "format", Enum: []string{"csv", "parquet"}, Description: "File format, csv by default"}},
+		Content: []string{"text/csv", "application/vnd.apache.parquet"}},
+	{Method: "GET", Path: "/cur", ID: "getCURStatus", Tag: "costs",
+		Summary: "Get the CUR source and ingested files", Response: models.CURStatus{}},
+	{Method: "POST", Path: "/cur/sync", ID: "syncCUR", Tag: "costs",
+		Summary: "Ingest new and changed CUR files now", Response: models.CURStatus{}},
+	{Method: "GET", Path: "/cur/cost", ID: "getResourceCost", Tag: "costs",
+		Summary: "Get the hourly or daily cost of a resource from CUR line items",
+		Query: []openapi.Param{
+			{Name: "resourceId", Required: true, Description: "Resource ID or ARN"},
+			{Name: "start", Description: "Start date or RFC 3339 time, seven days ago by default"},
+			{Name: "end", Description: "End date or RFC 3339 time, the next hour by default"},
+			{Name: "granularity", Enum: []string{"hourly", "daily"}, Description: "Bucket size, hourly by default"},
+		},
+		Response: models.ResourceCost{}},
+	{Method: "GET", Path: "/query", ID: "runQuery", Tag: "queries", List: true,
+		Summary:  "List the resources matching a query",
+		Query:    []openapi.Param{{Name: "query", Required: true, Description: "Resource query"}},
+		Response: []models.Resource{}},
+	{Method: "POST", Path: "/query", ID: "runQueryPost", Tag: "queries", List: true,
+		Summary: "List the resources matching a query in the request body",
+		Body: struct {
+			Query string `json:"query"`
+		}{},
+		Response: []models.Resource{}},
+	{Method: "GET", Path: "/queries", ID: "listSavedQueries", Tag: "queries",
+		Summary: "List saved queries", Response: []models.SavedQuery{}},
+	{Method: "POST", Path: "/queries", ID: "saveQuery", Tag: "queries",
+		Summary: "Create or replace a saved query", Body: models.SavedQuery{}, Response: models.SavedQuery{}},
+	{Method: "GET", Path: "/queries/:name", ID: "getSavedQuery", Tag: "queries",
+		Summary: "Get a saved query", Response: models.SavedQuery{}},
+	{Method: "DELETE", Path: "/queries/:name", ID: "deleteSavedQuery", Tag: "queries", Status: 204,
+		Summary: "Delete a saved query"},
+	{Method: "GET", Path: "/queries/:name/results", ID: "runSavedQuery", Tag: "queries", List: true,
+		Summary: "List the resources matching a saved query", Response: []models.Resource{}},
+	{Method: "GET", Path: "/openapi.json", ID: "getOpenAPI", Tag: "meta",
+		Summary: "Get this OpenAPI document", Response: map[string]any{}},
+}
+
+// Spec returns the OpenAPI document of the resource server
+func Spec() *openapi.Document {
+	doc := openapi.New("AWS Resources Cost Board resource API", "1.0.0", "/api")
+	doc.Info.Description = "Inventory, cost and query API of the resource server (cmd/server)."
+	doc.Add(routes...)
+
+	// Details hold a different struct for each resource type
+	resource := doc.Schema(models.Resource{})
+	resource.Properties["details"] = doc.AnyOf(models.DetailTypes...)
+	resource.Properties["details"].Nullable = true
+	return doc
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/listing"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/rql"
+)
+
+// RunQuery handles GET /api/query?query= and POST /api/query with {"query": "..."}. The
+// matches can be sorted and paginated like /api/resources.
+func (s *Server) RunQuery(c *gin.Context) {
+	src := c.Query("query")
+	if c.Request.Method == "POST" {
+		var body struct {
+			Query string `json:"query"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		src = body.Query
+	}
+
+	resources, err := s.resourceService.RunQuery(src)
+	if err != nil {
+		queryError(c, err)
+		return
+	}
+	listing.Respond(c, resources, "query")
+}
+
+// GetSavedQueries handles GET /api/queries
+func (s *Server) GetSavedQueries(c *gin.Context) {
+	c.JSON(200, s.resourceService.GetSavedQueries())
+}
+
+// GetSavedQuery handles GET /api/queries/:name
+func (s *Server) GetSavedQuery(c *gin.Context) {
+	query, ok := s.resourceService.GetSavedQuery(c.Param("name"))
+	if !ok {
+		c.JSON(404, gin.H{"error": "query not found"})
+		return
+	}
+	c.JSON(200, query)
+}
+
+// SaveQuery handles POST /api/queries, creating or replacing a saved query by name
+func (s *Server) SaveQuery(c *gin.Context) {
+	var query models.SavedQuery
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	saved, err := s.resourceService.SaveQuery(query)
+	if err != nil {
+		queryError(c, err)
+		return
+	}
+	c.JSON(200, saved)
+}
+
+// DeleteSavedQuery handles DELETE /api/queries/:name
+func (s *Server) DeleteSavedQuery(c *gin.Context) {
+	if !s.resourceService.DeleteSavedQuery(c.Param("name")) {
+		c.JSON(404, gin.H{"error": "query not found"})
+		return
+	}
+	c.Status(204)
+}
+
+// RunSavedQuery handles GET /api/queries/:name/results
+func (s *Server) RunSavedQuery(c *gin.Context) {
+	resources, ok := s.resourceService.RunSavedQuery(c.Param("name"))
+	if !ok {
+		c.JSON(404, gin.H{"error": "query not found"})
+		return
+	}
+	listing.Respond(c, resources)
+}
+
+// queryError responds 400 with the message and, for syntax and type errors, the position
+func queryError(c *gin.Context, err error) {
+	var queryErr *rql.Error
+	if errors.As(err, &queryErr) {
+		c.JSON(400, gin.H{"error": err.Error(), "position": queryErr.Pos})
+		return
+	}
+	c.JSON(400, gin.H{"error": err.Error()})
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -10,6 +11,9 @@ import (
 
 	"github.com/devesh-kumar/aws-resources-cost-board/internal/aws"
 	"github.com/devesh-kumar/aws-resources-cost-board/internal/config"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/cur"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/listing"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
 	"github.com/devesh-kumar/aws-resources-cost-board/internal/services"
 )
 
@@ -22,37 +26,55 @@ type Server struct {
 
 // NewServer creates a new API server
 func NewServer(cfg *config.Config) *Server {
+	// Create AWS client
+	awsClient, err := aws.NewClient(context.Background(), cfg.AWSRegion)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to create AWS client: %v", err))
+	}
+	awsClient.UnusedSecretDays = cfg.UnusedSecretDays
+
+	resourceService := services.NewResourceService(awsClient)
+	if cfg.SavedQueriesFile != "" {
+		if err := resourceService.LoadSavedQueries(cfg.SavedQueriesFile); err != nil {
+			panic(fmt.Sprintf("Failed to load saved queries: %v", err))
+		}
+	}
+	if cfg.CURPath != "" {
+		source, err := cur.NewSource(cfg.CURPath, awsClient.S3Client)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to open CUR source: %v", err))
+		}
+		resourceService.EnableCUR(cur.NewStore(source))
+	}
+
+	server := newServer(cfg, resourceService)
+
+	// Set up periodic refresh
+	go server.startPeriodicRefresh()
+
+	return server
+}
+
+// newServer creates a server with its routes for a resource service
+func newServer(cfg *config.Config, resourceService *services.ResourceService) *Server {
 	router := gin.Default()
 
 	// Set up CORS
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{cfg.CorsAllowed},
-		AllowMethods:     []string{"GET", "POST"},
+		AllowMethods:     []string{"GET", "POST", "DELETE"},
 		AllowHeaders:     []string{"Origin", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
+		ExposeHeaders:    append([]string{"Content-Length", "Content-Disposition"}, listing.Headers...),
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
-	// Create AWS client
-	awsClient, err := aws.NewClient(context.Background(), cfg.AWSRegion)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to create AWS client: %v", err))
-	}
-
-	resourceService := services.NewResourceService(awsClient)
-
 	server := &Server{
 		router:          router,
 		config:          cfg,
 		resourceService: resourceService,
 	}
-
 	server.setupRoutes()
-
-	// Set up periodic refresh
-	go server.startPeriodicRefresh()
-
 	return server
 }
 
@@ -63,6 +85,37 @@ func (s *Server) setupRoutes() {
 		api.GET("/resources", s.GetResources)
 		api.GET("/cost-summary", s.GetCostSummary)
 		api.POST("/refresh", s.RefreshData)
+		api.GET("/jobs", s.GetJobs)
+		api.GET("/jobs/:id", s.GetJob)
+		api.GET("/history", s.GetHistory)
+		api.GET("/history/:id", s.GetSnapshot)
+		api.GET("/compute-groups", s.GetComputeGroups)
+		api.GET("/network-summary", s.GetNetworkSummary)
+		api.GET("/collectors", s.GetCollectors)
+		api.GET("/events", s.StreamEvents)
+		api.GET("/events/ws", s.StreamEventsWebSocket)
+		api.GET("/recommendations", s.GetRecommendations)
+		api.GET("/export", s.ExportData)
+		api.GET("/focus/export", s.ExportFOCUS)
+		api.GET("/cur", s.GetCURStatus)
+		api.POST("/cur/sync", s.SyncCUR)
+		api.GET("/cur/cost", s.GetResourceCost)
+		api.GET("/query", s.RunQuery)
+		api.POST("/query", s.RunQuery)
+		api.GET("/queries", s.GetSavedQueries)
+		api.POST("/queries", s.SaveQuery)
+		api.GET("/queries/:name", s.GetSavedQuery)
+		api.DELETE("/queries/:name", s.DeleteSavedQuery)
+		api.GET("/queries/:name/results", s.RunSavedQuery)
+	}
+
+	// Prometheus scrapes /metrics by default, so it sits outside /api
+	s.router.GET("/metrics", s.GetMetrics)
+
+	spec := Spec()
+	api.GET("/openapi.json", spec.Handler())
+	for _, route := range spec.Undocumented(s.router.Routes()) {
+		log.Printf("Route %s is missing from the OpenAPI document", route)
 	}
 }
 
@@ -78,14 +131,14 @@ func (s *Server) startPeriodicRefresh() {
 
 	for {
 		<-ticker.C
-		s.resourceService.RefreshData(context.Background())
+		s.resourceService.StartRefresh(services.TriggerPeriodic)
 	}
 }
 
-// GetResources handles GET /api/resources
+// GetResources handles GET /api/resources, applying the list query
 func (s *Server) GetResources(c *gin.Context) {
 	resources := s.resourceService.GetAllResources()
-	c.JSON(200, resources)
+	listing.Respond(c, resources)
 }
 
 // GetCostSummary handles GET /api/cost-summary
@@ -94,12 +147,63 @@ func (s *Server) GetCostSummary(c *gin.Context) {
 	c.JSON(200, summary)
 }
 
-// RefreshData handles POST /api/refresh
+// GetComputeGroups handles GET /api/compute-groups
+func (s *Server) GetComputeGroups(c *gin.Context) {
+	groups := s.resourceService.GetComputeGroups()
+	c.JSON(200, groups)
+}
+
+// GetNetworkSummary handles GET /api/network-summary
+func (s *Server) GetNetworkSummary(c *gin.Context) {
+	summary := s.resourceService.GetNetworkSummary()
+	c.JSON(200, summary)
+}
+
+// GetCollectors handles GET /api/collectors
+func (s *Server) GetCollectors(c *gin.Context) {
+	collectors := s.resourceService.Registry().Collectors()
+	result := make([]models.CollectorInfo, 0, len(collectors))
+	for _, collector := range collectors {
+		result = append(result, models.CollectorInfo{Name: collector.Name(), ResourceType: collector.ResourceType()})
+	}
+	c.JSON(200, result)
+}
+
+// RefreshData handles POST /api/refresh by starting a refresh job, or returning the one
+// already running
 func (s *Server) RefreshData(c *gin.Context) {
-	err := s.resourceService.RefreshData(c)
-	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+	job, coalesced := s.resourceService.StartRefresh(services.TriggerAPI)
+	c.JSON(202, models.RefreshStarted{JobID: job.ID, Coalesced: coalesced, Job: job})
+}
+
+// GetJobs handles GET /api/jobs
+func (s *Server) GetJobs(c *gin.Context) {
+	jobs := s.resourceService.GetJobs()
+	c.JSON(200, jobs)
+}
+
+// GetJob handles GET /api/jobs/:id
+func (s *Server) GetJob(c *gin.Context) {
+	job, ok := s.resourceService.GetJob(c.Param("id"))
+	if !ok {
+		c.JSON(404, gin.H{"error": "job not found"})
+		return
+	}
+	c.JSON(200, job)
+}
+
+// GetHistory handles GET /api/history, optionally narrowed to one collector with ?collector=
+func (s *Server) GetHistory(c *gin.Context) {
+	history := s.resourceService.GetHistory(c.Query("collector"))
+	c.JSON(200, history)
+}
+
+// GetSnapshot handles GET /api/history/:id
+func (s *Server) GetSnapshot(c *gin.Context) {
+	snapshot, ok := s.resourceService.GetSnapshot(c.Param("id"))
+	if !ok {
+		c.JSON(404, gin.H{"error": "snapshot not found"})
 		return
 	}
-	c.JSON(200, gin.H{"message": "Data refreshed successfully"})
+	c.JSON(200, snapshot)
 }
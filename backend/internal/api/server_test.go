@@ -0,0 +1,161 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/collector"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/config"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/cur"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/services"
+)
+
+// stubResources has a resource with each kind of details, some tagged and flagged
+var stubResources = []models.Resource{
+	{ID: "i-1", Name: "web", Type: models.EC2Instance, Status: "running", DailyCost: 2, MonthlyCost: 60,
+		Details: models.EC2InstanceDetails{}, Tags: []models.Tag{{Key: "env", Value: "prod"}},
+		Flags: []models.Flag{{Code: "idle", Message: "CPU below 5%"}}},
+	{ID: "cluster", Name: "cluster", Type: models.EKSCluster, Status: "ACTIVE", Details: models.EKSClusterDetails{}},
+	{ID: "cluster/workers", Name: "workers", Type: models.EKSNodeGroup, ParentID: "cluster", Details: models.EKSNodeGroupDetails{}},
+	{ID: "cluster/fargate", Name: "fargate", Type: models.EKSFargateProfile, ParentID: "cluster", Details: models.EKSFargateProfileDetails{}},
+	{ID: "ecs", Name: "ecs", Type: models.ECSCluster, Details: models.ECSClusterDetails{}},
+	{ID: "ecs/api", Name: "api", Type: models.ECSService, ParentID: "ecs", Details: models.ECSServiceDetails{}},
+	{ID: "bucket", Name: "bucket", Type: models.S3Bucket, Details: models.S3BucketDetails{}},
+	{ID: "function", Name: "function", Type: models.LambdaFunction, Details: models.LambdaFunctionDetails{}},
+	{ID: "alb", Name: "alb", Type: models.LoadBalancer, Details: models.LoadBalancerDetails{}},
+	{ID: "nat-1", Name: "nat", Type: models.NATGateway, MonthlyCost: 32, Details: models.NATGatewayDetails{}},
+	{ID: "table", Name: "table", Type: models.DynamoDBTable, Details: models.DynamoDBTableDetails{},
+		Flags: []models.Flag{{Code: "switch-billing-mode", MonthlySavings: 12}}},
+	{ID: "cache", Name: "cache", Type: models.ElastiCacheCluster, Details: models.ManagedClusterDetails{}},
+	{ID: "efs", Name: "efs", Type: models.EFSFileSystem, Details: models.EFSFileSystemDetails{}},
+	{ID: "fsx", Name: "fsx", Type: models.FSxFileSystem, Details: models.FSxFileSystemDetails{}},
+	{ID: "vpce-1", Name: "endpoint", Type: models.VPCEndpoint, Details: models.VPCEndpointDetails{}},
+	{ID: "tgw-attach-1", Name: "attachment", Type: models.TransitGatewayAttachment, Details: models.TransitGatewayAttachmentDetails{}},
+	{ID: "vpn-1", Name: "vpn", Type: models.VPNConnection, Details: models.VPNConnectionDetails{}},
+	{ID: "repo", Name: "repo", Type: models.ECRRepository, Details: models.ECRRepositoryDetails{}},
+	{ID: "secret", Name: "secret", Type: models.SecretsManagerSecret, Details: models.UnitFeeDetails{}},
+	{ID: "vol-1", Name: "data", Type: models.EBSVolume, Status: "available", Details: models.EBSVolumeDetails{SizeGiB: 500}},
+	{ID: "/aws/lambda/function", Name: "/aws/lambda/function", Type: models.CloudWatchLogGroup, Details: models.LogGroupDetails{}},
+	{ID: "alarm", Name: "alarm", Type: models.CloudWatchAlarm},
+}
+
+// newTestServer returns a server whose inventory is stubResources, refreshed once, with a saved
+// query and an ingested CUR file
+func newTestServer(t *testing.T) (*Server, models.RefreshJob) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	service := services.NewOfflineResourceService("123456789012", "us-east-1")
+	service.Registry().MustRegister(collector.New("stub", models.EC2Instance,
+		func(context.Context, string, string) ([]models.Resource, error) { return stubResources, nil }))
+
+	dir := t.TempDir()
+	report := "lineItem/ResourceId,lineItem/UsageStartDate,lineItem/UsageEndDate,lineItem/UnblendedCost\n" +
+		"i-1,2026-09-01T00:00:00Z,2026-09-02T00:00:00Z,48\n"
+	if err := os.WriteFile(filepath.Join(dir, "report.csv"), []byte(report), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	service.EnableCUR(cur.NewStore(cur.DirSource(dir)))
+
+	server := newServer(&config.Config{CorsAllowed: "http://localhost:3000"}, service)
+
+	var started models.RefreshStarted
+	serve(t, server, http.MethodPost, "/api/refresh", nil, http.StatusAccepted, &started)
+	var job models.RefreshJob
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		serve(t, server, http.MethodGet, "/api/jobs/"+started.JobID, nil, http.StatusOK, &job)
+		if job.Status != models.JobPending && job.Status != models.JobRunning {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("refresh did not finish")
+		}
+	}
+
+	serve(t, server, http.MethodPost, "/api/queries",
+		models.SavedQuery{Name: "unattached", Query: `type = "EBSVolume" AND state = "available"`}, http.StatusOK, nil)
+	serve(t, server, http.MethodPost, "/api/cur/sync", nil, http.StatusOK, nil)
+	return server, job
+}
+
+// serve sends a request to the server, checks the status and decodes the JSON response into out
+func serve(t *testing.T, server *Server, method, target string, body any, status int, out any) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader *bytes.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest(method, target, reader))
+	if recorder.Code != status {
+		t.Fatalf("%s %s = %d %s, want %d", method, target, recorder.Code, recorder.Body, status)
+	}
+	if out != nil {
+		if err := json.Unmarshal(recorder.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: %v", method, target, err)
+		}
+	}
+	return recorder
+}
+
+func TestDocumentedResponses(t *testing.T) {
+	server, job := newTestServer(t)
+	if job.Status != models.JobSucceeded {
+		t.Fatalf("refresh status = %s", job.Status)
+	}
+
+	// Values for path and required query parameters
+	pathValues := map[string]string{"{id}": job.ID, "{name}": "unattached"}
+	queryValues := url.Values{"resourceId": {"i-1"}, "query": {`type = "EC2Instance"`},
+		"start": {"2026-09-01"}, "end": {"2026-09-02"}}
+
+	spec := Spec()
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		op := (*spec.Paths[path])["get"]
+		// Event streams do not end, so they are not served here
+		if op == nil || strings.HasPrefix(path, "/events") {
+			continue
+		}
+		t.Run(op.OperationID, func(t *testing.T) {
+			target := path
+			for name, value := range pathValues {
+				target = strings.ReplaceAll(target, name, url.PathEscape(value))
+			}
+			query := url.Values{}
+			for _, param := range op.Parameters {
+				if param.In == "query" && queryValues.Has(param.Name) {
+					query[param.Name] = queryValues[param.Name]
+				}
+			}
+			if len(query) > 0 {
+				target += "?" + query.Encode()
+			}
+
+			recorder := serve(t, server, http.MethodGet, "/api"+target, nil, http.StatusOK, nil)
+			if err := spec.ValidateResponse(http.MethodGet, path, recorder.Code, recorder.Body.Bytes()); err != nil {
+				t.Errorf("GET %s does not match the document: %v", target, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package aws
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// listCloudWatchAlarms returns metric and composite alarms. Metric alarms are billed per metric
+// they evaluate, at a higher rate when their period is under a minute.
+func (c *Client) listCloudWatchAlarms(ctx context.Context) ([]unitFeeItem, error) {
+	var items []unitFeeItem
+
+	paginator := cloudwatch.NewDescribeAlarmsPaginator(c.CloudWatchClient, &cloudwatch.DescribeAlarmsInput{
+		AlarmTypes: []types.AlarmType{types.AlarmTypeMetricAlarm, types.AlarmTypeCompositeAlarm},
+	})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, alarm := range result.MetricAlarms {
+			period := int32Value(alarm.Period)
+			metrics := 0
+			for _, query := range alarm.Metrics {
+				if query.MetricStat != nil {
+					metrics++
+					if query.MetricStat.Period != nil {
+						period = *query.MetricStat.Period
+					}
+				}
+			}
+			unit := "cloudwatch:alarm"
+			if period > 0 && period < 60 {
+				unit = "cloudwatch:high-resolution-alarm"
+			}
+
+			items = append(items, unitFeeItem{
+				ID:        derefString(alarm.AlarmArn),
+				Name:      derefString(alarm.AlarmName),
+				Status:    string(alarm.StateValue),
+				CreatedAt: timeValue(alarm.AlarmConfigurationUpdatedTimestamp),
+				Unit:      unit,
+				Units:     float64(max(metrics, 1)),
+				Attributes: map[string]string{
+					"namespace":  derefString(alarm.Namespace),
+					"metricName": derefString(alarm.MetricName),
+					"period":     strconv.Itoa(int(period)),
+				},
+			})
+		}
+
+		for _, alarm := range result.CompositeAlarms {
+			items = append(items, unitFeeItem{
+				ID:        derefString(alarm.AlarmArn),
+				Name:      derefString(alarm.AlarmName),
+				Status:    string(alarm.StateValue),
+				CreatedAt: timeValue(alarm.AlarmConfigurationUpdatedTimestamp),
+				Unit:      "cloudwatch:composite-alarm",
+				Units:     1,
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// listCustomMetricNamespaces returns one item per custom metric namespace, counting the metrics
+// that received data in the last two weeks
+func (c *Client) listCustomMetricNamespaces(ctx context.Context) ([]unitFeeItem, error) {
+	counts := make(map[string]int)
+
+	paginator := cloudwatch.NewListMetricsPaginator(c.CloudWatchClient, &cloudwatch.ListMetricsInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, metric := range result.Metrics {
+			namespace := derefString(metric.Namespace)
+			if !strings.HasPrefix(namespace, "AWS/") {
+				counts[namespace]++
+			}
+		}
+	}
+
+	namespaces := make([]string, 0, len(counts))
+	for namespace := range counts {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	items := make([]unitFeeItem, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		items = append(items, unitFeeItem{
+			ID:     c.Region + "/" + namespace,
+			Name:   namespace,
+			Status: "active",
+			Units:  float64(counts[namespace]),
+			Attributes: map[string]string{
+				"metrics": strconv.Itoa(counts[namespace]),
+			},
+		})
+	}
+	return items, nil
+}
@@ -4,16 +4,59 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/efs"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/fsx"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/opensearch"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/redshift"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
 // Client encapsulates all AWS service clients
 type Client struct {
-	EC2Client          *ec2.Client
-	RDSClient          *rds.Client
-	CostExplorerClient *costexplorer.Client
+	Region               string
+	EC2Client            *ec2.Client
+	RDSClient            *rds.Client
+	CostExplorerClient   *costexplorer.Client
+	S3Client             *s3.Client
+	CloudWatchClient     *cloudwatch.Client
+	CloudWatchLogsClient *cloudwatchlogs.Client
+	LambdaClient         *lambda.Client
+	ELBClient            *elasticloadbalancing.Client
+	ELBv2Client          *elasticloadbalancingv2.Client
+	DynamoDBClient       *dynamodb.Client
+	ElastiCacheClient    *elasticache.Client
+	OpenSearchClient     *opensearch.Client
+	RedshiftClient       *redshift.Client
+	EKSClient            *eks.Client
+	ECSClient            *ecs.Client
+	EFSClient            *efs.Client
+	FSxClient            *fsx.Client
+	ECRClient            *ecr.Client
+	SecretsManagerClient *secretsmanager.Client
+	KMSClient            *kms.Client
+	Route53Client        *route53.Client
+	STSClient            *sts.Client
+
+	// UnusedSecretDays is the number of days without access after which a secret is flagged as
+	// unused; zero uses DefaultUnusedSecretDays
+	UnusedSecretDays int
 }
 
 // NewClient creates a new AWS client
@@ -24,8 +67,28 @@ func NewClient(ctx context.Context, region string) (*Client, error) {
 	}
 
 	return &Client{
-		EC2Client:          ec2.NewFromConfig(cfg),
-		RDSClient:          rds.NewFromConfig(cfg),
-		CostExplorerClient: costexplorer.NewFromConfig(cfg),
+		Region:               region,
+		EC2Client:            ec2.NewFromConfig(cfg),
+		RDSClient:            rds.NewFromConfig(cfg),
+		CostExplorerClient:   costexplorer.NewFromConfig(cfg),
+		S3Client:             s3.NewFromConfig(cfg),
+		CloudWatchClient:     cloudwatch.NewFromConfig(cfg),
+		CloudWatchLogsClient: cloudwatchlogs.NewFromConfig(cfg),
+		LambdaClient:         lambda.NewFromConfig(cfg),
+		ELBClient:            elasticloadbalancing.NewFromConfig(cfg),
+		ELBv2Client:          elasticloadbalancingv2.NewFromConfig(cfg),
+		DynamoDBClient:       dynamodb.NewFromConfig(cfg),
+		ElastiCacheClient:    elasticache.NewFromConfig(cfg),
+		OpenSearchClient:     opensearch.NewFromConfig(cfg),
+		RedshiftClient:       redshift.NewFromConfig(cfg),
+		EKSClient:            eks.NewFromConfig(cfg),
+		ECSClient:            ecs.NewFromConfig(cfg),
+		EFSClient:            efs.NewFromConfig(cfg),
+		FSxClient:            fsx.NewFromConfig(cfg),
+		ECRClient:            ecr.NewFromConfig(cfg),
+		SecretsManagerClient: secretsmanager.NewFromConfig(cfg),
+		KMSClient:            kms.NewFromConfig(cfg),
+		Route53Client:        route53.NewFromConfig(cfg),
+		STSClient:            sts.NewFromConfig(cfg),
 	}, nil
 }
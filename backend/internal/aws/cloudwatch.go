@@ -0,0 +1,152 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// metricQuery describes a single CloudWatch metric lookup
+type metricQuery struct {
+	Namespace  string
+	MetricName string
+	Dimensions map[string]string
+	Statistic  types.Statistic
+	Period     time.Duration
+	Lookback   time.Duration
+	Region     string
+}
+
+// getMetricDatapoints returns the datapoints of a metric over the query's lookback window
+func (c *Client) getMetricDatapoints(ctx context.Context, q metricQuery) ([]types.Datapoint, error) {
+	end := time.Now()
+	start := end.Add(-q.Lookback)
+
+	input := &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  &q.Namespace,
+		MetricName: &q.MetricName,
+		StartTime:  &start,
+		EndTime:    &end,
+		Period:     int32Ptr(int32(q.Period.Seconds())),
+		Statistics: []types.Statistic{q.Statistic},
+	}
+	for name, value := range q.Dimensions {
+		input.Dimensions = append(input.Dimensions, types.Dimension{
+			Name:  stringPtr(name),
+			Value: stringPtr(value),
+		})
+	}
+
+	result, err := c.CloudWatchClient.GetMetricStatistics(ctx, input, withCloudWatchRegion(q.Region))
+	if err != nil {
+		return nil, err
+	}
+	return result.Datapoints, nil
+}
+
+// latestMetricValue returns the most recent datapoint value of a metric, or 0 when there is none
+func (c *Client) latestMetricValue(ctx context.Context, q metricQuery) (float64, error) {
+	datapoints, err := c.getMetricDatapoints(ctx, q)
+	if err != nil {
+		return 0, err
+	}
+
+	var latest time.Time
+	value := 0.0
+	for _, dp := range datapoints {
+		if dp.Timestamp != nil && dp.Timestamp.After(latest) {
+			latest = *dp.Timestamp
+			value = datapointValue(dp, q.Statistic)
+		}
+	}
+	return value, nil
+}
+
+// sumMetricValues returns the sum of all datapoint values of a metric over the lookback window
+func (c *Client) sumMetricValues(ctx context.Context, q metricQuery) (float64, error) {
+	datapoints, err := c.getMetricDatapoints(ctx, q)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+	for _, dp := range datapoints {
+		total += datapointValue(dp, q.Statistic)
+	}
+	return total, nil
+}
+
+// listMetricDimensionValues returns the distinct values of one dimension across all metrics
+// with the given name that match the filter dimensions
+func (c *Client) listMetricDimensionValues(ctx context.Context, namespace, metricName string, filter map[string]string, dimension, region string) ([]string, error) {
+	input := &cloudwatch.ListMetricsInput{
+		Namespace:  &namespace,
+		MetricName: &metricName,
+	}
+	for name, value := range filter {
+		input.Dimensions = append(input.Dimensions, types.DimensionFilter{
+			Name:  stringPtr(name),
+			Value: stringPtr(value),
+		})
+	}
+
+	seen := make(map[string]bool)
+	var values []string
+	paginator := cloudwatch.NewListMetricsPaginator(c.CloudWatchClient, input)
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx, withCloudWatchRegion(region))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, metric := range result.Metrics {
+			for _, dim := range metric.Dimensions {
+				if dim.Name != nil && *dim.Name == dimension && dim.Value != nil && !seen[*dim.Value] {
+					seen[*dim.Value] = true
+					values = append(values, *dim.Value)
+				}
+			}
+		}
+	}
+	return values, nil
+}
+
+// datapointValue extracts the value of the requested statistic from a datapoint
+func datapointValue(dp types.Datapoint, statistic types.Statistic) float64 {
+	var value *float64
+	switch statistic {
+	case types.StatisticSum:
+		value = dp.Sum
+	case types.StatisticMaximum:
+		value = dp.Maximum
+	case types.StatisticMinimum:
+		value = dp.Minimum
+	case types.StatisticSampleCount:
+		value = dp.SampleCount
+	default:
+		value = dp.Average
+	}
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+// withCloudWatchRegion sends a CloudWatch request to the given region instead of the client default
+func withCloudWatchRegion(region string) func(*cloudwatch.Options) {
+	return func(o *cloudwatch.Options) {
+		if region != "" {
+			o.Region = region
+		}
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,366 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	elasticachetypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/aws/aws-sdk-go-v2/service/opensearch"
+	"github.com/aws/aws-sdk-go-v2/service/redshift"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+// idleLookback is the window over which a managed cluster must show no activity to be flagged idle
+const idleLookback = 7 * 24 * time.Hour
+
+// maxDescribeDomainsBatch is the number of OpenSearch domains that can be described in one call
+const maxDescribeDomainsBatch = 5
+
+// GetElastiCacheClusters returns ElastiCache replication groups and standalone cache clusters.
+// Clusters that belong to a replication group are folded into the group to avoid double counting.
+func (c *Client) GetElastiCacheClusters(ctx context.Context) ([]models.Resource, error) {
+	var clusters []elasticachetypes.CacheCluster
+	clusterPaginator := elasticache.NewDescribeCacheClustersPaginator(c.ElastiCacheClient, &elasticache.DescribeCacheClustersInput{})
+	for clusterPaginator.HasMorePages() {
+		result, err := clusterPaginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing ElastiCache clusters: %v", err)
+			return nil, err
+		}
+		clusters = append(clusters, result.CacheClusters...)
+	}
+
+	clustersByID := make(map[string]elasticachetypes.CacheCluster, len(clusters))
+	for _, cluster := range clusters {
+		clustersByID[*cluster.CacheClusterId] = cluster
+	}
+
+	var resources []models.Resource
+
+	groupPaginator := elasticache.NewDescribeReplicationGroupsPaginator(c.ElastiCacheClient, &elasticache.DescribeReplicationGroupsInput{})
+	for groupPaginator.HasMorePages() {
+		result, err := groupPaginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing ElastiCache replication groups: %v", err)
+			return nil, err
+		}
+
+		for _, group := range result.ReplicationGroups {
+			id := *group.ReplicationGroupId
+			details := models.ManagedClusterDetails{ARN: derefString(group.ARN)}
+
+			var createdAt time.Time
+			for _, memberID := range group.MemberClusters {
+				member, ok := clustersByID[memberID]
+				if !ok {
+					continue
+				}
+				details.Engine = derefString(member.Engine)
+				details.EngineVersion = derefString(member.EngineVersion)
+				addNodeGroup(&details, "elasticache", "cache", derefString(member.CacheNodeType), int32Value(member.NumCacheNodes))
+				createdAt = timeValue(member.CacheClusterCreateTime)
+			}
+
+			c.measureElastiCacheIdle(ctx, group.MemberClusters, &details)
+			resources = append(resources, newManagedClusterResource(id, id, models.ElastiCacheCluster,
+				derefString(group.Status), c.Region, createdAt, details, c.getElastiCacheTags(ctx, group.ARN)))
+		}
+	}
+
+	for _, cluster := range clusters {
+		if cluster.ReplicationGroupId != nil {
+			continue
+		}
+
+		id := *cluster.CacheClusterId
+		details := models.ManagedClusterDetails{
+			ARN:           derefString(cluster.ARN),
+			Engine:        derefString(cluster.Engine),
+			EngineVersion: derefString(cluster.EngineVersion),
+		}
+		addNodeGroup(&details, "elasticache", "cache", derefString(cluster.CacheNodeType), int32Value(cluster.NumCacheNodes))
+
+		c.measureElastiCacheIdle(ctx, []string{id}, &details)
+		resources = append(resources, newManagedClusterResource(id, id, models.ElastiCacheCluster,
+			derefString(cluster.CacheClusterStatus), c.Region, timeValue(cluster.CacheClusterCreateTime),
+			details, c.getElastiCacheTags(ctx, cluster.ARN)))
+	}
+
+	return resources, nil
+}
+
+// measureElastiCacheIdle records the peak client connections across the given cache clusters.
+// The peak is unknown when any cluster's connections could not be read.
+func (c *Client) measureElastiCacheIdle(ctx context.Context, clusterIDs []string, details *models.ManagedClusterDetails) {
+	details.IdleMetric = "CurrConnections"
+	details.IdleMetricKnown = true
+	for _, id := range clusterIDs {
+		peak, err := c.peakMetricValue(ctx, "AWS/ElastiCache", "CurrConnections", map[string]string{"CacheClusterId": id})
+		if err != nil {
+			log.Printf("Error reading connections for ElastiCache cluster %s: %v", id, err)
+			details.IdleMetricKnown = false
+			continue
+		}
+		details.IdleMetricValue = max(details.IdleMetricValue, peak)
+	}
+}
+
+// getElastiCacheTags returns the tags of an ElastiCache cluster or replication group
+func (c *Client) getElastiCacheTags(ctx context.Context, arn *string) []models.Tag {
+	if arn == nil {
+		return nil
+	}
+	result, err := c.ElastiCacheClient.ListTagsForResource(ctx, &elasticache.ListTagsForResourceInput{ResourceName: arn})
+	if err != nil {
+		log.Printf("Error listing tags of ElastiCache resource %s: %v", *arn, err)
+		return nil
+	}
+
+	var tags []models.Tag
+	for _, tag := range result.TagList {
+		tags = append(tags, models.Tag{Key: derefString(tag.Key), Value: derefString(tag.Value)})
+	}
+	return tags
+}
+
+// GetOpenSearchDomains returns all OpenSearch domains with their node configuration and estimated cost
+func (c *Client) GetOpenSearchDomains(ctx context.Context) ([]models.Resource, error) {
+	list, err := c.OpenSearchClient.ListDomainNames(ctx, &opensearch.ListDomainNamesInput{})
+	if err != nil {
+		log.Printf("Error listing OpenSearch domains: %v", err)
+		return nil, err
+	}
+
+	names := make([]string, 0, len(list.DomainNames))
+	for _, domain := range list.DomainNames {
+		names = append(names, *domain.DomainName)
+	}
+
+	var resources []models.Resource
+	for start := 0; start < len(names); start += maxDescribeDomainsBatch {
+		end := min(start+maxDescribeDomainsBatch, len(names))
+		result, err := c.OpenSearchClient.DescribeDomains(ctx, &opensearch.DescribeDomainsInput{DomainNames: names[start:end]})
+		if err != nil {
+			log.Printf("Error describing OpenSearch domains: %v", err)
+			return nil, err
+		}
+
+		for _, domain := range result.DomainStatusList {
+			name := *domain.DomainName
+			details := models.ManagedClusterDetails{
+				ARN:           derefString(domain.ARN),
+				Engine:        "opensearch",
+				EngineVersion: derefString(domain.EngineVersion),
+			}
+
+			if config := domain.ClusterConfig; config != nil {
+				addNodeGroup(&details, "opensearch", "data", string(config.InstanceType), int32Value(config.InstanceCount))
+				if config.DedicatedMasterEnabled != nil && *config.DedicatedMasterEnabled {
+					addNodeGroup(&details, "opensearch", "master", string(config.DedicatedMasterType), int32Value(config.DedicatedMasterCount))
+				}
+				if config.WarmEnabled != nil && *config.WarmEnabled {
+					addNodeGroup(&details, "opensearch", "warm", string(config.WarmType), int32Value(config.WarmCount))
+				}
+
+				if ebs := domain.EBSOptions; ebs != nil && ebs.EBSEnabled != nil && *ebs.EBSEnabled {
+					details.StorageGB = int32Value(ebs.VolumeSize) * int32Value(config.InstanceCount)
+					details.StorageMonthlyCost = pricing.OpenSearchStorageMonthly(details.StorageGB)
+				}
+			}
+
+			// Domain metrics are keyed by the owning account, which is part of the ARN
+			details.IdleMetric = "SearchRate+IndexingRate"
+			details.IdleMetricKnown = true
+			dimensions := map[string]string{"DomainName": name, "ClientId": arnAccount(details.ARN)}
+			for _, metric := range []string{"SearchRate", "IndexingRate"} {
+				peak, err := c.peakMetricValue(ctx, "AWS/ES", metric, dimensions)
+				if err != nil {
+					log.Printf("Error reading %s for OpenSearch domain %s: %v", metric, name, err)
+					details.IdleMetricKnown = false
+					continue
+				}
+				details.IdleMetricValue += peak
+			}
+
+			status := "active"
+			if domain.Processing != nil && *domain.Processing {
+				status = "processing"
+			}
+
+			var tags []models.Tag
+			tagResult, err := c.OpenSearchClient.ListTags(ctx, &opensearch.ListTagsInput{ARN: domain.ARN})
+			if err != nil {
+				log.Printf("Error listing tags of OpenSearch domain %s: %v", name, err)
+			} else {
+				for _, tag := range tagResult.TagList {
+					tags = append(tags, models.Tag{Key: derefString(tag.Key), Value: derefString(tag.Value)})
+				}
+			}
+
+			// OpenSearch does not expose a domain creation date
+			resources = append(resources, newManagedClusterResource(details.ARN, name, models.OpenSearchDomain,
+				status, c.Region, time.Time{}, details, tags))
+		}
+	}
+
+	return resources, nil
+}
+
+// GetRedshiftClusters returns all Redshift clusters with their node configuration and estimated cost.
+// Paused clusters are not charged for compute.
+func (c *Client) GetRedshiftClusters(ctx context.Context) ([]models.Resource, error) {
+	var resources []models.Resource
+
+	paginator := redshift.NewDescribeClustersPaginator(c.RedshiftClient, &redshift.DescribeClustersInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing Redshift clusters: %v", err)
+			return nil, err
+		}
+
+		for _, cluster := range result.Clusters {
+			id := *cluster.ClusterIdentifier
+			status := derefString(cluster.ClusterStatus)
+
+			details := models.ManagedClusterDetails{
+				ARN:           derefString(cluster.ClusterNamespaceArn),
+				Engine:        "redshift",
+				EngineVersion: derefString(cluster.ClusterVersion),
+			}
+			addNodeGroup(&details, "redshift", "compute", derefString(cluster.NodeType), int32Value(cluster.NumberOfNodes))
+			if status == "paused" {
+				details.ComputeMonthlyCost = 0
+			}
+
+			details.IdleMetric = "DatabaseConnections"
+			peak, err := c.peakMetricValue(ctx, "AWS/Redshift", "DatabaseConnections", map[string]string{"ClusterIdentifier": id})
+			if err != nil {
+				log.Printf("Error reading connections for Redshift cluster %s: %v", id, err)
+			}
+			details.IdleMetricValue = peak
+			details.IdleMetricKnown = err == nil
+
+			var tags []models.Tag
+			for _, tag := range cluster.Tags {
+				tags = append(tags, models.Tag{Key: derefString(tag.Key), Value: derefString(tag.Value)})
+			}
+
+			resources = append(resources, newManagedClusterResource(id, id, models.RedshiftCluster,
+				status, c.Region, timeValue(cluster.ClusterCreateTime), details, tags))
+		}
+	}
+
+	return resources, nil
+}
+
+// addNodeGroup adds a group of identical nodes to a cluster and accumulates its compute cost
+func addNodeGroup(details *models.ManagedClusterDetails, service, role, nodeType string, count int32) {
+	hourly, known := pricing.NodeHourly(service, nodeType)
+	if len(details.NodeGroups) == 0 {
+		details.PriceKnown = true
+	}
+	details.PriceKnown = details.PriceKnown && known
+
+	for i, group := range details.NodeGroups {
+		if group.Role == role && group.NodeType == nodeType {
+			details.NodeGroups[i].Count += count
+			details.ComputeMonthlyCost += hourly * float64(count) * pricing.HoursPerMonth
+			return
+		}
+	}
+
+	details.NodeGroups = append(details.NodeGroups, models.NodeGroup{
+		Role:       role,
+		NodeType:   nodeType,
+		Count:      count,
+		HourlyCost: hourly,
+	})
+	details.ComputeMonthlyCost += hourly * float64(count) * pricing.HoursPerMonth
+}
+
+// newManagedClusterResource builds a managed cluster resource and its review flags
+func newManagedClusterResource(id, name string, resourceType models.ResourceType, status, region string,
+	createdAt time.Time, details models.ManagedClusterDetails, tags []models.Tag) models.Resource {
+	monthlyCost := details.ComputeMonthlyCost + details.StorageMonthlyCost
+
+	var flags []models.Flag
+	// A metric that could not be read says nothing about whether the cluster is idle
+	if details.IdleMetricKnown && details.IdleMetricValue == 0 && !strings.EqualFold(status, "paused") {
+		flags = append(flags, models.Flag{
+			Code:    "idle",
+			Message: fmt.Sprintf("No %s recorded in the last 7 days", details.IdleMetric),
+		})
+	}
+	if !details.PriceKnown {
+		flags = append(flags, models.Flag{
+			Code:    "unknown-node-price",
+			Message: "Cost estimate is incomplete because a node type has no list price",
+		})
+	}
+
+	return models.Resource{
+		ID:          id,
+		Name:        name,
+		Type:        resourceType,
+		Region:      region,
+		Status:      status,
+		CreatedAt:   createdAt,
+		Details:     details,
+		DailyCost:   monthlyCost / 30,
+		MonthlyCost: monthlyCost,
+		Tags:        tags,
+		Flags:       flags,
+	}
+}
+
+// peakMetricValue returns the maximum of a metric over the idle lookback window
+func (c *Client) peakMetricValue(ctx context.Context, namespace, metricName string, dimensions map[string]string) (float64, error) {
+	datapoints, err := c.getMetricDatapoints(ctx, metricQuery{
+		Namespace:  namespace,
+		MetricName: metricName,
+		Dimensions: dimensions,
+		Statistic:  types.StatisticMaximum,
+		Period:     24 * time.Hour,
+		Lookback:   idleLookback,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	peak := 0.0
+	for _, dp := range datapoints {
+		peak = max(peak, datapointValue(dp, types.StatisticMaximum))
+	}
+	return peak, nil
+}
+
+// arnAccount returns the account ID field of an ARN
+func arnAccount(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 5 {
+		return ""
+	}
+	return parts[4]
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func int32Value(i *int32) int32 {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/collector"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// ClientPool creates and reuses one client per region
+type ClientPool struct {
+	mu               sync.Mutex
+	clients          map[string]*Client
+	unusedSecretDays int
+}
+
+// NewClientPool creates a pool seeded with existing clients. Clients created for other regions
+// take their settings from the first seed.
+func NewClientPool(clients ...*Client) *ClientPool {
+	pool := &ClientPool{clients: make(map[string]*Client)}
+	for _, client := range clients {
+		pool.clients[client.Region] = client
+	}
+	if len(clients) > 0 {
+		pool.unusedSecretDays = clients[0].UnusedSecretDays
+	}
+	return pool
+}
+
+// Client returns the client for a region, creating it on first use
+func (p *ClientPool) Client(ctx context.Context, region string) (*Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if client, ok := p.clients[region]; ok {
+		return client, nil
+	}
+	client, err := NewClient(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	client.UnusedSecretDays = p.unusedSecretDays
+	p.clients[region] = client
+	return client, nil
+}
+
+// Collectors returns the built-in AWS collectors, which run against the pool's client for the
+// requested region
+func (p *ClientPool) Collectors() []collector.Collector {
+	collectors := []collector.Collector{
+		p.collector("ec2", models.EC2Instance, (*Client).GetEC2Instances),
+		p.collector("ebs", models.EBSVolume, (*Client).GetEBSVolumes),
+		p.collector("s3", models.S3Bucket, (*Client).GetS3Buckets),
+		p.collector("lambda", models.LambdaFunction, (*Client).GetLambdaFunctions),
+		p.collector("loadbalancer", models.LoadBalancer, (*Client).GetLoadBalancers),
+		p.collector("natgateway", models.NATGateway, (*Client).GetNATGateways),
+		p.collector("dynamodb", models.DynamoDBTable, (*Client).GetDynamoDBTables),
+		p.collector("elasticache", models.ElastiCacheCluster, (*Client).GetElastiCacheClusters),
+		p.collector("opensearch", models.OpenSearchDomain, (*Client).GetOpenSearchDomains),
+		p.collector("redshift", models.RedshiftCluster, (*Client).GetRedshiftClusters),
+		p.collector("eks", models.EKSCluster, (*Client).GetEKSClusters),
+		p.collector("ecs", models.ECSCluster, (*Client).GetECSClusters),
+		p.collector("efs", models.EFSFileSystem, (*Client).GetEFSFileSystems),
+		p.collector("fsx", models.FSxFileSystem, (*Client).GetFSxFileSystems),
+		p.collector("ecr", models.ECRRepository, (*Client).GetECRRepositories),
+		p.collector("vpcendpoint", models.VPCEndpoint, (*Client).GetVPCEndpoints),
+		p.collector("transitgateway", models.TransitGatewayAttachment, (*Client).GetTransitGatewayAttachments),
+		p.collector("vpn", models.VPNConnection, (*Client).GetVPNConnections),
+		p.collector("logs", models.CloudWatchLogGroup, (*Client).GetLogGroups),
+	}
+
+	for _, fee := range unitFeeCollectors {
+		fee := fee
+		collectors = append(collectors, p.collector(fee.Name, fee.ResourceType, func(c *Client, ctx context.Context) ([]models.Resource, error) {
+			return c.collectUnitFees(ctx, fee)
+		}))
+	}
+
+	return collectors
+}
+
+// collector wraps a client method as a collector
+func (p *ClientPool) collector(name string, resourceType models.ResourceType,
+	get func(c *Client, ctx context.Context) ([]models.Resource, error)) collector.Collector {
+	return collector.New(name, resourceType, func(ctx context.Context, account, region string) ([]models.Resource, error) {
+		client, err := p.Client(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return get(client, ctx)
+	})
+}
+
+// AccountID returns the ID of the account the client's credentials belong to
+func (c *Client) AccountID(ctx context.Context) (string, error) {
+	result, err := c.STSClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+	return derefString(result.Account), nil
+}
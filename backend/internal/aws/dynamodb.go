@@ -0,0 +1,255 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamotypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+const (
+	// dynamoDBTargetUtilization is the share of provisioned capacity a right-sized table
+	// is expected to use at its observed peak
+	dynamoDBTargetUtilization = 0.7
+
+	// capacityModeSavingsRatio is the minimum relative saving before a billing mode switch is recommended
+	capacityModeSavingsRatio = 0.2
+
+	// capacityModeMinSavings is the minimum monthly saving in USD before a switch is recommended
+	capacityModeMinSavings = 1.0
+)
+
+// GetDynamoDBTables returns all DynamoDB tables with estimated monthly cost and billing mode advice
+func (c *Client) GetDynamoDBTables(ctx context.Context) ([]models.Resource, error) {
+	var tables []models.Resource
+
+	paginator := dynamodb.NewListTablesPaginator(c.DynamoDBClient, &dynamodb.ListTablesInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error listing DynamoDB tables: %v", err)
+			return nil, err
+		}
+
+		for _, name := range result.TableNames {
+			table, err := c.describeDynamoDBTable(ctx, name)
+			if err != nil {
+				log.Printf("Error describing DynamoDB table %s: %v", name, err)
+				continue
+			}
+			tables = append(tables, table)
+		}
+	}
+
+	return tables, nil
+}
+
+// describeDynamoDBTable gathers the configuration, usage and cost of a table
+func (c *Client) describeDynamoDBTable(ctx context.Context, name string) (models.Resource, error) {
+	result, err := c.DynamoDBClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: &name})
+	if err != nil {
+		return models.Resource{}, err
+	}
+	table := result.Table
+
+	details := models.DynamoDBTableDetails{
+		ARN:                    *table.TableArn,
+		BillingMode:            string(dynamotypes.BillingModeProvisioned),
+		GlobalSecondaryIndexes: len(table.GlobalSecondaryIndexes),
+	}
+	if table.BillingModeSummary != nil && table.BillingModeSummary.BillingMode != "" {
+		details.BillingMode = string(table.BillingModeSummary.BillingMode)
+	}
+	if table.TableSizeBytes != nil {
+		details.SizeBytes = *table.TableSizeBytes
+	}
+	if table.ItemCount != nil {
+		details.ItemCount = *table.ItemCount
+	}
+	addProvisionedThroughput(&details, table.ProvisionedThroughput)
+	for _, index := range table.GlobalSecondaryIndexes {
+		addProvisionedThroughput(&details, index.ProvisionedThroughput)
+	}
+
+	ttl, err := c.DynamoDBClient.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{TableName: &name})
+	if err != nil {
+		log.Printf("Error describing TTL of DynamoDB table %s: %v", name, err)
+	} else if ttl.TimeToLiveDescription != nil {
+		details.TTLEnabled = ttl.TimeToLiveDescription.TimeToLiveStatus == dynamotypes.TimeToLiveStatusEnabled
+	}
+
+	backups, err := c.DynamoDBClient.DescribeContinuousBackups(ctx, &dynamodb.DescribeContinuousBackupsInput{TableName: &name})
+	if err != nil {
+		log.Printf("Error describing continuous backups of DynamoDB table %s: %v", name, err)
+	} else if backups.ContinuousBackupsDescription != nil && backups.ContinuousBackupsDescription.PointInTimeRecoveryDescription != nil {
+		details.PITREnabled = backups.ContinuousBackupsDescription.PointInTimeRecoveryDescription.PointInTimeRecoveryStatus ==
+			dynamotypes.PointInTimeRecoveryStatusEnabled
+	}
+
+	var tags []models.Tag
+	tagResult, err := c.DynamoDBClient.ListTagsOfResource(ctx, &dynamodb.ListTagsOfResourceInput{ResourceArn: table.TableArn})
+	if err != nil {
+		log.Printf("Error listing tags of DynamoDB table %s: %v", name, err)
+	} else {
+		for _, tag := range tagResult.Tags {
+			tags = append(tags, models.Tag{Key: *tag.Key, Value: *tag.Value})
+		}
+	}
+
+	indexNames := []string{""}
+	for _, index := range table.GlobalSecondaryIndexes {
+		indexNames = append(indexNames, *index.IndexName)
+	}
+	usage, err := c.measureDynamoDBUsage(ctx, name, indexNames)
+	if err != nil {
+		log.Printf("Error reading metrics for DynamoDB table %s: %v", name, err)
+	} else {
+		details.MonthlyConsumedRCU = usage.MonthlyConsumedRCU
+		details.MonthlyConsumedWCU = usage.MonthlyConsumedWCU
+		details.PeakConsumedRCUPerSec = usage.PeakConsumedRCUPerSec
+		details.PeakConsumedWCUPerSec = usage.PeakConsumedWCUPerSec
+		details.UsageKnown = true
+	}
+
+	// Without the observed traffic neither mode can be priced against it, so no switch is
+	// advised; provisioned capacity is still billed whatever the traffic
+	var capacityCost float64
+	if details.UsageKnown {
+		capacityCost = adviseCapacityMode(&details)
+	} else if details.BillingMode != string(dynamotypes.BillingModePayPerRequest) {
+		details.ProvisionedMonthlyCost = pricing.DynamoDBProvisionedMonthly(
+			float64(details.ProvisionedRCU), float64(details.ProvisionedWCU))
+		capacityCost = details.ProvisionedMonthlyCost
+	}
+	monthlyCost := capacityCost + pricing.DynamoDBStorageMonthly(details.SizeBytes, details.PITREnabled)
+
+	var flags []models.Flag
+	if details.RecommendedBillingMode != "" {
+		flags = append(flags, models.Flag{
+			Code: "switch-billing-mode",
+			Message: fmt.Sprintf("Switching to %s would save about $%.2f per month at observed traffic",
+				details.RecommendedBillingMode, details.EstimatedMonthlySavings),
+			MonthlySavings: details.EstimatedMonthlySavings,
+		})
+	}
+
+	return models.Resource{
+		ID:          details.ARN,
+		Name:        name,
+		Type:        models.DynamoDBTable,
+		Region:      c.Region,
+		Status:      string(table.TableStatus),
+		CreatedAt:   timeValue(table.CreationDateTime),
+		Details:     details,
+		DailyCost:   monthlyCost / 30,
+		MonthlyCost: monthlyCost,
+		Tags:        tags,
+		Flags:       flags,
+	}, nil
+}
+
+// dynamoDBUsage is the consumed capacity of a table and its global secondary indexes
+type dynamoDBUsage struct {
+	MonthlyConsumedRCU    float64
+	MonthlyConsumedWCU    float64
+	PeakConsumedRCUPerSec float64
+	PeakConsumedWCUPerSec float64
+}
+
+// measureDynamoDBUsage returns consumed capacity over the last 30 days for the table and
+// its global secondary indexes. An empty index name refers to the table itself.
+func (c *Client) measureDynamoDBUsage(ctx context.Context, table string, indexNames []string) (dynamoDBUsage, error) {
+	var usage dynamoDBUsage
+	for _, index := range indexNames {
+		dimensions := map[string]string{"TableName": table}
+		if index != "" {
+			dimensions["GlobalSecondaryIndexName"] = index
+		}
+
+		for _, metric := range []string{"ConsumedReadCapacityUnits", "ConsumedWriteCapacityUnits"} {
+			datapoints, err := c.getMetricDatapoints(ctx, metricQuery{
+				Namespace:  "AWS/DynamoDB",
+				MetricName: metric,
+				Dimensions: dimensions,
+				Statistic:  types.StatisticSum,
+				Period:     time.Hour,
+				Lookback:   30 * 24 * time.Hour,
+			})
+			if err != nil {
+				return dynamoDBUsage{}, err
+			}
+
+			total, peakHour := 0.0, 0.0
+			for _, dp := range datapoints {
+				value := datapointValue(dp, types.StatisticSum)
+				total += value
+				peakHour = math.Max(peakHour, value)
+			}
+
+			// Peaks of the table and each index are added, which may overstate
+			// the combined peak when they do not coincide
+			if metric == "ConsumedReadCapacityUnits" {
+				usage.MonthlyConsumedRCU += total
+				usage.PeakConsumedRCUPerSec += peakHour / 3600
+			} else {
+				usage.MonthlyConsumedWCU += total
+				usage.PeakConsumedWCUPerSec += peakHour / 3600
+			}
+		}
+	}
+	return usage, nil
+}
+
+// adviseCapacityMode prices the observed traffic in both billing modes, records a recommendation
+// when switching would save money, and returns the table's current monthly capacity cost
+func adviseCapacityMode(details *models.DynamoDBTableDetails) float64 {
+	details.OnDemandMonthlyCost = pricing.DynamoDBOnDemandMonthly(details.MonthlyConsumedRCU, details.MonthlyConsumedWCU)
+
+	if details.BillingMode == string(dynamotypes.BillingModePayPerRequest) {
+		// Size provisioned capacity to the observed peak at the target utilization
+		rcu := math.Max(1, math.Ceil(details.PeakConsumedRCUPerSec/dynamoDBTargetUtilization))
+		wcu := math.Max(1, math.Ceil(details.PeakConsumedWCUPerSec/dynamoDBTargetUtilization))
+		details.ProvisionedMonthlyCost = pricing.DynamoDBProvisionedMonthly(rcu, wcu)
+
+		recommendCapacityMode(details, details.OnDemandMonthlyCost, details.ProvisionedMonthlyCost,
+			string(dynamotypes.BillingModeProvisioned))
+		return details.OnDemandMonthlyCost
+	}
+
+	details.ProvisionedMonthlyCost = pricing.DynamoDBProvisionedMonthly(
+		float64(details.ProvisionedRCU), float64(details.ProvisionedWCU))
+
+	recommendCapacityMode(details, details.ProvisionedMonthlyCost, details.OnDemandMonthlyCost,
+		string(dynamotypes.BillingModePayPerRequest))
+	return details.ProvisionedMonthlyCost
+}
+
+// recommendCapacityMode records a switch to the alternative mode when it is sufficiently cheaper
+func recommendCapacityMode(details *models.DynamoDBTableDetails, current, alternative float64, mode string) {
+	savings := current - alternative
+	if savings >= capacityModeMinSavings && savings >= current*capacityModeSavingsRatio {
+		details.RecommendedBillingMode = mode
+		details.EstimatedMonthlySavings = savings
+	}
+}
+
+// addProvisionedThroughput adds a table's or index's provisioned capacity to the totals
+func addProvisionedThroughput(details *models.DynamoDBTableDetails, throughput *dynamotypes.ProvisionedThroughputDescription) {
+	if throughput == nil {
+		return
+	}
+	if throughput.ReadCapacityUnits != nil {
+		details.ProvisionedRCU += *throughput.ReadCapacityUnits
+	}
+	if throughput.WriteCapacityUnits != nil {
+		details.ProvisionedWCU += *throughput.WriteCapacityUnits
+	}
+}
@@ -0,0 +1,84 @@
+package aws
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+// gibibyte is the number of bytes in a GiB, the unit EBS volume sizes are given in
+const gibibyte = 1 << 30
+
+// GetEBSVolumes returns EBS volumes with their estimated monthly storage cost, flagging volumes
+// that are not attached to an instance
+func (c *Client) GetEBSVolumes(ctx context.Context) ([]models.Resource, error) {
+	var volumes []models.Resource
+
+	paginator := ec2.NewDescribeVolumesPaginator(c.EC2Client, &ec2.DescribeVolumesInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing EBS volumes: %v", err)
+			return nil, err
+		}
+
+		for _, volume := range result.Volumes {
+			volumes = append(volumes, c.newEBSVolumeResource(volume))
+		}
+	}
+
+	return volumes, nil
+}
+
+// newEBSVolumeResource builds the resource for an EBS volume
+func (c *Client) newEBSVolumeResource(volume ec2types.Volume) models.Resource {
+	id := *volume.VolumeId
+	details := models.EBSVolumeDetails{
+		VolumeType:       string(volume.VolumeType),
+		SizeGiB:          int32Value(volume.Size),
+		Iops:             int32Value(volume.Iops),
+		Throughput:       int32Value(volume.Throughput),
+		AvailabilityZone: derefString(volume.AvailabilityZone),
+	}
+	details.SizeBytes = int64(details.SizeGiB) * gibibyte
+	if volume.Encrypted != nil {
+		details.Encrypted = *volume.Encrypted
+	}
+	for _, attachment := range volume.Attachments {
+		if attachment.InstanceId != nil {
+			details.AttachedTo = *attachment.InstanceId
+			break
+		}
+	}
+
+	var flags []models.Flag
+	if volume.State == ec2types.VolumeStateAvailable {
+		flags = append(flags, models.Flag{
+			Code:    "unattached-volume",
+			Message: "Volume is not attached to any instance but is still billed for its provisioned size",
+		})
+	}
+
+	tags := tagsFromEC2(volume.Tags)
+	monthlyCost := pricing.EBSVolumeMonthly(details.VolumeType, details.SizeGiB)
+
+	return models.Resource{
+		ID:          id,
+		Name:        nameFromTags(tags, id),
+		Type:        models.EBSVolume,
+		Region:      c.Region,
+		Status:      string(volume.State),
+		CreatedAt:   timeValue(volume.CreateTime),
+		Details:     details,
+		DailyCost:   monthlyCost / 30,
+		MonthlyCost: monthlyCost,
+		Tags:        tags,
+		Flags:       flags,
+		ParentID:    details.AttachedTo,
+	}
+}
@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+// GetEC2Instances returns all EC2 instances that have not been terminated.
+// Only running instances accrue compute cost.
+func (c *Client) GetEC2Instances(ctx context.Context) ([]models.Resource, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   stringPtr("instance-state-name"),
+				Values: []string{"pending", "running", "stopping", "stopped"},
+			},
+		},
+	}
+
+	var instances []models.Resource
+	paginator := ec2.NewDescribeInstancesPaginator(c.EC2Client, input)
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing EC2 instances: %v", err)
+			return nil, err
+		}
+
+		for _, reservation := range result.Reservations {
+			for _, instance := range reservation.Instances {
+				instances = append(instances, newEC2InstanceResource(instance, c.Region))
+			}
+		}
+	}
+
+	return instances, nil
+}
+
+// newEC2InstanceResource converts an EC2 instance into a resource
+func newEC2InstanceResource(instance ec2types.Instance, region string) models.Resource {
+	id := *instance.InstanceId
+	instanceType := string(instance.InstanceType)
+	state := ""
+	if instance.State != nil {
+		state = string(instance.State.Name)
+	}
+
+	hourly, known := pricing.EC2InstanceHourly(instanceType)
+	details := models.EC2InstanceDetails{
+		InstanceType: instanceType,
+		PrivateIP:    derefString(instance.PrivateIpAddress),
+		PublicIP:     derefString(instance.PublicIpAddress),
+		Lifecycle:    string(instance.InstanceLifecycle),
+		PriceKnown:   known,
+	}
+	if details.Lifecycle == "" {
+		details.Lifecycle = "on-demand"
+	}
+	if instance.Placement != nil {
+		details.AvailabilityZone = derefString(instance.Placement.AvailabilityZone)
+	}
+
+	monthlyCost := 0.0
+	if state == string(ec2types.InstanceStateNameRunning) {
+		monthlyCost = hourly * pricing.HoursPerMonth
+	}
+
+	var flags []models.Flag
+	if !known {
+		flags = append(flags, models.Flag{
+			Code:    "unknown-instance-price",
+			Message: "Cost estimate is unavailable because the instance type has no list price",
+		})
+	}
+
+	tags := tagsFromEC2(instance.Tags)
+
+	return models.Resource{
+		ID:          id,
+		Name:        nameFromTags(tags, id),
+		Type:        models.EC2Instance,
+		Region:      region,
+		Status:      state,
+		CreatedAt:   timeValue(instance.LaunchTime),
+		Details:     details,
+		DailyCost:   monthlyCost / 30,
+		MonthlyCost: monthlyCost,
+		Tags:        tags,
+		Flags:       flags,
+	}
+}
@@ -0,0 +1,153 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	ecrtypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+const (
+	// ecrKeepTaggedImages is the number of tagged images kept by the simulated lifecycle policy
+	ecrKeepTaggedImages = 20
+
+	// ecrMinPolicySavings is the minimum monthly saving in USD before a lifecycle policy is recommended
+	ecrMinPolicySavings = 1.0
+)
+
+// GetECRRepositories returns ECR repositories with image storage, estimated cost and the
+// savings of a lifecycle policy keeping the most recent tagged images
+func (c *Client) GetECRRepositories(ctx context.Context) ([]models.Resource, error) {
+	var repositories []models.Resource
+
+	paginator := ecr.NewDescribeRepositoriesPaginator(c.ECRClient, &ecr.DescribeRepositoriesInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing ECR repositories: %v", err)
+			return nil, err
+		}
+
+		for _, repository := range result.Repositories {
+			resource, err := c.describeECRRepository(ctx, repository)
+			if err != nil {
+				log.Printf("Error describing images of ECR repository %s: %v", *repository.RepositoryName, err)
+				continue
+			}
+			repositories = append(repositories, resource)
+		}
+	}
+
+	return repositories, nil
+}
+
+// describeECRRepository gathers the images, lifecycle policy and tags of a repository
+func (c *Client) describeECRRepository(ctx context.Context, repository ecrtypes.Repository) (models.Resource, error) {
+	name := *repository.RepositoryName
+
+	var images []ecrtypes.ImageDetail
+	paginator := ecr.NewDescribeImagesPaginator(c.ECRClient, &ecr.DescribeImagesInput{RepositoryName: &name})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return models.Resource{}, err
+		}
+		images = append(images, result.ImageDetails...)
+	}
+
+	details := models.ECRRepositoryDetails{
+		ARN:        derefString(repository.RepositoryArn),
+		URI:        derefString(repository.RepositoryUri),
+		ImageCount: len(images),
+	}
+	for _, image := range images {
+		size := int64Value(image.ImageSizeInBytes)
+		details.SizeBytes += size
+		if len(image.ImageTags) == 0 {
+			details.UntaggedImageCount++
+			details.UntaggedSizeBytes += size
+		} else {
+			details.TaggedImageCount++
+		}
+	}
+	details.LifecycleSimulation = simulateECRLifecyclePolicy(images, ecrKeepTaggedImages)
+
+	_, err := c.ECRClient.GetLifecyclePolicy(ctx, &ecr.GetLifecyclePolicyInput{RepositoryName: &name})
+	if err == nil {
+		details.HasLifecyclePolicy = true
+		details.LifecyclePolicyKnown = true
+	} else if isAPIError(err, "LifecyclePolicyNotFoundException") {
+		details.LifecyclePolicyKnown = true
+	} else {
+		log.Printf("Error getting lifecycle policy of ECR repository %s: %v", name, err)
+	}
+
+	var flags []models.Flag
+	if details.LifecyclePolicyKnown && !details.HasLifecyclePolicy && details.LifecycleSimulation.EstimatedMonthlySavings >= ecrMinPolicySavings {
+		flags = append(flags, models.Flag{
+			Code: "add-lifecycle-policy",
+			Message: fmt.Sprintf("A lifecycle policy expiring untagged images and keeping the last %d tagged images would save about $%.2f per month",
+				ecrKeepTaggedImages, details.LifecycleSimulation.EstimatedMonthlySavings),
+			MonthlySavings: details.LifecycleSimulation.EstimatedMonthlySavings,
+		})
+	}
+
+	var tags []models.Tag
+	tagResult, err := c.ECRClient.ListTagsForResource(ctx, &ecr.ListTagsForResourceInput{ResourceArn: repository.RepositoryArn})
+	if err != nil {
+		log.Printf("Error listing tags of ECR repository %s: %v", name, err)
+	} else {
+		for _, tag := range tagResult.Tags {
+			tags = append(tags, models.Tag{Key: derefString(tag.Key), Value: derefString(tag.Value)})
+		}
+	}
+
+	monthlyCost := pricing.ECRStorageMonthly(details.SizeBytes)
+
+	return models.Resource{
+		ID:          details.ARN,
+		Name:        name,
+		Type:        models.ECRRepository,
+		Region:      c.Region,
+		Status:      "active",
+		CreatedAt:   timeValue(repository.CreatedAt),
+		Details:     details,
+		DailyCost:   monthlyCost / 30,
+		MonthlyCost: monthlyCost,
+		Tags:        tags,
+		Flags:       flags,
+	}, nil
+}
+
+// simulateECRLifecyclePolicy returns what a policy expiring every untagged image and all but the
+// keep most recently pushed tagged images would remove
+func simulateECRLifecyclePolicy(images []ecrtypes.ImageDetail, keep int) models.ECRPolicyResult {
+	result := models.ECRPolicyResult{KeepTaggedImages: keep}
+
+	var tagged []ecrtypes.ImageDetail
+	for _, image := range images {
+		if len(image.ImageTags) == 0 {
+			result.ExpiredImages++
+			result.ExpiredSizeBytes += int64Value(image.ImageSizeInBytes)
+		} else {
+			tagged = append(tagged, image)
+		}
+	}
+
+	sort.Slice(tagged, func(i, j int) bool {
+		return timeValue(tagged[i].ImagePushedAt).After(timeValue(tagged[j].ImagePushedAt))
+	})
+	for i := keep; i < len(tagged); i++ {
+		result.ExpiredImages++
+		result.ExpiredSizeBytes += int64Value(tagged[i].ImageSizeInBytes)
+	}
+
+	result.EstimatedMonthlySavings = pricing.ECRStorageMonthly(result.ExpiredSizeBytes)
+	return result
+}
@@ -0,0 +1,268 @@
+package aws
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+// ECS API batch limits
+const (
+	maxDescribeECSClusters           = 100
+	maxDescribeECSContainerInstances = 100
+	maxDescribeECSServices           = 10
+)
+
+// ecsClusterCapacity summarizes the EC2 container instances registered to a cluster
+type ecsClusterCapacity struct {
+	instanceIDs   []string
+	monthlyCost   float64
+	registeredCPU int32
+}
+
+// GetECSClusters returns ECS clusters followed by their services, which reference the cluster as parent
+func (c *Client) GetECSClusters(ctx context.Context) ([]models.Resource, error) {
+	var clusterARNs []string
+	paginator := ecs.NewListClustersPaginator(c.ECSClient, &ecs.ListClustersInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error listing ECS clusters: %v", err)
+			return nil, err
+		}
+		clusterARNs = append(clusterARNs, result.ClusterArns...)
+	}
+
+	var resources []models.Resource
+	taskDefinitions := make(map[string]*ecstypes.TaskDefinition)
+
+	for start := 0; start < len(clusterARNs); start += maxDescribeECSClusters {
+		end := min(start+maxDescribeECSClusters, len(clusterARNs))
+		result, err := c.ECSClient.DescribeClusters(ctx, &ecs.DescribeClustersInput{
+			Clusters: clusterARNs[start:end],
+			Include:  []ecstypes.ClusterField{ecstypes.ClusterFieldTags},
+		})
+		if err != nil {
+			log.Printf("Error describing ECS clusters: %v", err)
+			return nil, err
+		}
+
+		for _, cluster := range result.Clusters {
+			arn := *cluster.ClusterArn
+			name := *cluster.ClusterName
+
+			capacity, err := c.getECSClusterCapacity(ctx, arn)
+			if err != nil {
+				log.Printf("Error describing container instances of ECS cluster %s: %v", name, err)
+			}
+
+			details := models.ECSClusterDetails{
+				ARN:                   arn,
+				ActiveServices:        cluster.ActiveServicesCount,
+				RunningTasks:          cluster.RunningTasksCount,
+				ContainerInstanceIDs:  capacity.instanceIDs,
+				ContainerInstanceCost: capacity.monthlyCost,
+			}
+
+			resources = append(resources, models.Resource{
+				ID:      arn,
+				Name:    name,
+				Type:    models.ECSCluster,
+				Region:  c.Region,
+				Status:  derefString(cluster.Status),
+				Details: details,
+				Tags:    tagsFromECS(cluster.Tags),
+			})
+
+			services, err := c.getECSServices(ctx, arn, name, capacity, taskDefinitions)
+			if err != nil {
+				log.Printf("Error describing services of ECS cluster %s: %v", name, err)
+			}
+			resources = append(resources, services...)
+		}
+	}
+
+	return resources, nil
+}
+
+// getECSClusterCapacity returns the EC2 container instances of a cluster with their total
+// registered CPU and estimated monthly cost
+func (c *Client) getECSClusterCapacity(ctx context.Context, clusterARN string) (ecsClusterCapacity, error) {
+	capacity := ecsClusterCapacity{instanceIDs: make([]string, 0)}
+
+	var instanceARNs []string
+	paginator := ecs.NewListContainerInstancesPaginator(c.ECSClient, &ecs.ListContainerInstancesInput{Cluster: &clusterARN})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return capacity, err
+		}
+		instanceARNs = append(instanceARNs, result.ContainerInstanceArns...)
+	}
+
+	for start := 0; start < len(instanceARNs); start += maxDescribeECSContainerInstances {
+		end := min(start+maxDescribeECSContainerInstances, len(instanceARNs))
+		result, err := c.ECSClient.DescribeContainerInstances(ctx, &ecs.DescribeContainerInstancesInput{
+			Cluster:            &clusterARN,
+			ContainerInstances: instanceARNs[start:end],
+		})
+		if err != nil {
+			return capacity, err
+		}
+
+		for _, instance := range result.ContainerInstances {
+			if instance.Ec2InstanceId != nil {
+				capacity.instanceIDs = append(capacity.instanceIDs, *instance.Ec2InstanceId)
+			}
+			for _, attribute := range instance.Attributes {
+				if derefString(attribute.Name) == "ecs.instance-type" {
+					hourly, _ := pricing.EC2InstanceHourly(derefString(attribute.Value))
+					capacity.monthlyCost += hourly * pricing.HoursPerMonth
+				}
+			}
+			for _, resource := range instance.RegisteredResources {
+				if derefString(resource.Name) == "CPU" {
+					capacity.registeredCPU += resource.IntegerValue
+				}
+			}
+		}
+	}
+
+	return capacity, nil
+}
+
+// getECSServices returns the services of a cluster with their task size and cost
+func (c *Client) getECSServices(ctx context.Context, clusterARN, clusterName string, capacity ecsClusterCapacity,
+	taskDefinitions map[string]*ecstypes.TaskDefinition) ([]models.Resource, error) {
+	var serviceARNs []string
+	paginator := ecs.NewListServicesPaginator(c.ECSClient, &ecs.ListServicesInput{Cluster: &clusterARN})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		serviceARNs = append(serviceARNs, result.ServiceArns...)
+	}
+
+	var services []models.Resource
+	for start := 0; start < len(serviceARNs); start += maxDescribeECSServices {
+		end := min(start+maxDescribeECSServices, len(serviceARNs))
+		result, err := c.ECSClient.DescribeServices(ctx, &ecs.DescribeServicesInput{
+			Cluster:  &clusterARN,
+			Services: serviceARNs[start:end],
+			Include:  []ecstypes.ServiceField{ecstypes.ServiceFieldTags},
+		})
+		if err != nil {
+			return services, err
+		}
+
+		for _, service := range result.Services {
+			details := models.ECSServiceDetails{
+				ARN:            *service.ServiceArn,
+				ClusterName:    clusterName,
+				LaunchType:     ecsLaunchType(service),
+				TaskDefinition: derefString(service.TaskDefinition),
+				DesiredCount:   service.DesiredCount,
+				RunningCount:   service.RunningCount,
+			}
+
+			taskDefinition, err := c.getECSTaskDefinition(ctx, details.TaskDefinition, taskDefinitions)
+			if err != nil {
+				log.Printf("Error describing task definition %s: %v", details.TaskDefinition, err)
+			} else {
+				details.CPU, details.MemoryMB = ecsTaskSize(taskDefinition)
+			}
+
+			monthlyCost := 0.0
+			if details.LaunchType == string(ecstypes.LaunchTypeFargate) {
+				monthlyCost = float64(details.DesiredCount) *
+					pricing.FargateTaskMonthly(float64(details.CPU)/1024, float64(details.MemoryMB)/1024)
+			} else if capacity.registeredCPU > 0 {
+				share := float64(details.CPU) * float64(details.DesiredCount) / float64(capacity.registeredCPU)
+				details.AllocatedMonthlyCost = capacity.monthlyCost * min(share, 1)
+			}
+
+			services = append(services, models.Resource{
+				ID:          details.ARN,
+				Name:        derefString(service.ServiceName),
+				Type:        models.ECSService,
+				Region:      c.Region,
+				Status:      derefString(service.Status),
+				CreatedAt:   timeValue(service.CreatedAt),
+				Details:     details,
+				DailyCost:   monthlyCost / 30,
+				MonthlyCost: monthlyCost,
+				Tags:        tagsFromECS(service.Tags),
+				ParentID:    clusterARN,
+			})
+		}
+	}
+
+	return services, nil
+}
+
+// getECSTaskDefinition describes a task definition, caching results across services
+func (c *Client) getECSTaskDefinition(ctx context.Context, arn string, cache map[string]*ecstypes.TaskDefinition) (*ecstypes.TaskDefinition, error) {
+	if taskDefinition, ok := cache[arn]; ok {
+		return taskDefinition, nil
+	}
+
+	result, err := c.ECSClient.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{TaskDefinition: &arn})
+	if err != nil {
+		return nil, err
+	}
+	cache[arn] = result.TaskDefinition
+	return result.TaskDefinition, nil
+}
+
+// ecsLaunchType returns the launch type of a service, resolving capacity provider strategies
+func ecsLaunchType(service ecstypes.Service) string {
+	if service.LaunchType != "" {
+		return string(service.LaunchType)
+	}
+	for _, item := range service.CapacityProviderStrategy {
+		if strings.HasPrefix(derefString(item.CapacityProvider), "FARGATE") {
+			return string(ecstypes.LaunchTypeFargate)
+		}
+	}
+	return string(ecstypes.LaunchTypeEc2)
+}
+
+// ecsTaskSize returns the CPU units and memory (MB) of a task, falling back to the sum of its
+// containers when the task definition has no task-level size
+func ecsTaskSize(taskDefinition *ecstypes.TaskDefinition) (int, int) {
+	cpu, _ := strconv.Atoi(derefString(taskDefinition.Cpu))
+	memory, _ := strconv.Atoi(derefString(taskDefinition.Memory))
+
+	if cpu == 0 || memory == 0 {
+		containerCPU, containerMemory := 0, 0
+		for _, container := range taskDefinition.ContainerDefinitions {
+			containerCPU += int(container.Cpu)
+			containerMemory += int(int32Value(container.Memory))
+		}
+		if cpu == 0 {
+			cpu = containerCPU
+		}
+		if memory == 0 {
+			memory = containerMemory
+		}
+	}
+
+	return cpu, memory
+}
+
+// tagsFromECS converts ECS tags into resource tags
+func tagsFromECS(ecsTags []ecstypes.Tag) []models.Tag {
+	tags := make([]models.Tag, 0, len(ecsTags))
+	for _, tag := range ecsTags {
+		tags = append(tags, models.Tag{Key: derefString(tag.Key), Value: derefString(tag.Value)})
+	}
+	return tags
+}
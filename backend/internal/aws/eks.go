@@ -0,0 +1,188 @@
+package aws
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+// GetEKSClusters returns EKS clusters with their managed node groups and Fargate profiles.
+// Clusters carry the control-plane fee; node groups and profiles reference their cluster as parent.
+func (c *Client) GetEKSClusters(ctx context.Context) ([]models.Resource, error) {
+	var resources []models.Resource
+
+	paginator := eks.NewListClustersPaginator(c.EKSClient, &eks.ListClustersInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error listing EKS clusters: %v", err)
+			return nil, err
+		}
+
+		for _, name := range result.Clusters {
+			clusterResources, err := c.describeEKSCluster(ctx, name)
+			if err != nil {
+				log.Printf("Error describing EKS cluster %s: %v", name, err)
+				continue
+			}
+			resources = append(resources, clusterResources...)
+		}
+	}
+
+	return resources, nil
+}
+
+// describeEKSCluster returns the resources of one cluster: the cluster itself followed by
+// its node groups and Fargate profiles
+func (c *Client) describeEKSCluster(ctx context.Context, name string) ([]models.Resource, error) {
+	result, err := c.EKSClient.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: &name})
+	if err != nil {
+		return nil, err
+	}
+	cluster := result.Cluster
+	clusterARN := *cluster.Arn
+
+	nodeGroups, err := c.getEKSNodeGroups(ctx, name, clusterARN)
+	if err != nil {
+		log.Printf("Error listing node groups of EKS cluster %s: %v", name, err)
+	}
+
+	profiles, err := c.getEKSFargateProfiles(ctx, name, clusterARN)
+	if err != nil {
+		log.Printf("Error listing Fargate profiles of EKS cluster %s: %v", name, err)
+	}
+
+	details := models.EKSClusterDetails{
+		ARN:                     clusterARN,
+		Version:                 derefString(cluster.Version),
+		PlatformVersion:         derefString(cluster.PlatformVersion),
+		NodeGroups:              len(nodeGroups),
+		FargateProfiles:         len(profiles),
+		ControlPlaneMonthlyCost: pricing.EKSControlPlaneMonthly(),
+	}
+
+	resources := []models.Resource{{
+		ID:          clusterARN,
+		Name:        name,
+		Type:        models.EKSCluster,
+		Region:      c.Region,
+		Status:      string(cluster.Status),
+		CreatedAt:   timeValue(cluster.CreatedAt),
+		Details:     details,
+		DailyCost:   details.ControlPlaneMonthlyCost / 30,
+		MonthlyCost: details.ControlPlaneMonthlyCost,
+		Tags:        tagsFromMap(cluster.Tags),
+	}}
+	resources = append(resources, nodeGroups...)
+	resources = append(resources, profiles...)
+
+	return resources, nil
+}
+
+// getEKSNodeGroups returns the managed node groups of a cluster
+func (c *Client) getEKSNodeGroups(ctx context.Context, clusterName, clusterARN string) ([]models.Resource, error) {
+	var nodeGroups []models.Resource
+
+	paginator := eks.NewListNodegroupsPaginator(c.EKSClient, &eks.ListNodegroupsInput{ClusterName: &clusterName})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nodeGroups, err
+		}
+
+		for _, name := range result.Nodegroups {
+			described, err := c.EKSClient.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
+				ClusterName:   &clusterName,
+				NodegroupName: &name,
+			})
+			if err != nil {
+				log.Printf("Error describing node group %s of EKS cluster %s: %v", name, clusterName, err)
+				continue
+			}
+			group := described.Nodegroup
+
+			details := models.EKSNodeGroupDetails{
+				ARN:           *group.NodegroupArn,
+				ClusterName:   clusterName,
+				InstanceTypes: group.InstanceTypes,
+				CapacityType:  string(group.CapacityType),
+			}
+			if scaling := group.ScalingConfig; scaling != nil {
+				details.DesiredSize = int32Value(scaling.DesiredSize)
+				details.MinSize = int32Value(scaling.MinSize)
+				details.MaxSize = int32Value(scaling.MaxSize)
+			}
+			if group.Resources != nil {
+				for _, asg := range group.Resources.AutoScalingGroups {
+					details.AutoScalingGroups = append(details.AutoScalingGroups, derefString(asg.Name))
+				}
+			}
+
+			nodeGroups = append(nodeGroups, models.Resource{
+				ID:        details.ARN,
+				Name:      name,
+				Type:      models.EKSNodeGroup,
+				Region:    c.Region,
+				Status:    string(group.Status),
+				CreatedAt: timeValue(group.CreatedAt),
+				Details:   details,
+				Tags:      tagsFromMap(group.Tags),
+				ParentID:  clusterARN,
+			})
+		}
+	}
+
+	return nodeGroups, nil
+}
+
+// getEKSFargateProfiles returns the Fargate profiles of a cluster.
+// Fargate pods are billed per pod, so profiles carry no cost of their own.
+func (c *Client) getEKSFargateProfiles(ctx context.Context, clusterName, clusterARN string) ([]models.Resource, error) {
+	var profiles []models.Resource
+
+	paginator := eks.NewListFargateProfilesPaginator(c.EKSClient, &eks.ListFargateProfilesInput{ClusterName: &clusterName})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return profiles, err
+		}
+
+		for _, name := range result.FargateProfileNames {
+			described, err := c.EKSClient.DescribeFargateProfile(ctx, &eks.DescribeFargateProfileInput{
+				ClusterName:        &clusterName,
+				FargateProfileName: &name,
+			})
+			if err != nil {
+				log.Printf("Error describing Fargate profile %s of EKS cluster %s: %v", name, clusterName, err)
+				continue
+			}
+			profile := described.FargateProfile
+
+			details := models.EKSFargateProfileDetails{
+				ARN:         *profile.FargateProfileArn,
+				ClusterName: clusterName,
+			}
+			for _, selector := range profile.Selectors {
+				details.Namespaces = append(details.Namespaces, derefString(selector.Namespace))
+			}
+
+			profiles = append(profiles, models.Resource{
+				ID:        details.ARN,
+				Name:      name,
+				Type:      models.EKSFargateProfile,
+				Region:    c.Region,
+				Status:    string(profile.Status),
+				CreatedAt: timeValue(profile.CreatedAt),
+				Details:   details,
+				Tags:      tagsFromMap(profile.Tags),
+				ParentID:  clusterARN,
+			})
+		}
+	}
+
+	return profiles, nil
+}
@@ -0,0 +1,205 @@
+package aws
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/efs"
+	efstypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/aws/aws-sdk-go-v2/service/fsx"
+	fsxtypes "github.com/aws/aws-sdk-go-v2/service/fsx/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+// GetEFSFileSystems returns EFS file systems with their size by storage class and estimated monthly cost
+func (c *Client) GetEFSFileSystems(ctx context.Context) ([]models.Resource, error) {
+	var fileSystems []models.Resource
+
+	paginator := efs.NewDescribeFileSystemsPaginator(c.EFSClient, &efs.DescribeFileSystemsInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing EFS file systems: %v", err)
+			return nil, err
+		}
+
+		for _, fileSystem := range result.FileSystems {
+			fileSystems = append(fileSystems, c.newEFSFileSystemResource(ctx, fileSystem))
+		}
+	}
+
+	return fileSystems, nil
+}
+
+// newEFSFileSystemResource builds the resource for a file system, including its lifecycle policy
+func (c *Client) newEFSFileSystemResource(ctx context.Context, fileSystem efstypes.FileSystemDescription) models.Resource {
+	id := *fileSystem.FileSystemId
+	details := models.EFSFileSystemDetails{
+		ARN:              derefString(fileSystem.FileSystemArn),
+		PerformanceMode:  string(fileSystem.PerformanceMode),
+		ThroughputMode:   string(fileSystem.ThroughputMode),
+		AvailabilityZone: derefString(fileSystem.AvailabilityZoneName),
+		MountTargets:     fileSystem.NumberOfMountTargets,
+	}
+	if fileSystem.ProvisionedThroughputInMibps != nil {
+		details.ProvisionedThroughputInMibps = *fileSystem.ProvisionedThroughputInMibps
+	}
+	if size := fileSystem.SizeInBytes; size != nil {
+		details.SizeBytes = size.Value
+		details.StandardBytes = int64Value(size.ValueInStandard)
+		details.IABytes = int64Value(size.ValueInIA)
+		details.ArchiveBytes = int64Value(size.ValueInArchive)
+	}
+
+	lifecycle, err := c.EFSClient.DescribeLifecycleConfiguration(ctx, &efs.DescribeLifecycleConfigurationInput{FileSystemId: &id})
+	if err != nil {
+		log.Printf("Error describing lifecycle configuration of EFS file system %s: %v", id, err)
+	} else {
+		details.LifecycleKnown = true
+		for _, policy := range lifecycle.LifecyclePolicies {
+			if policy.TransitionToIA != "" {
+				details.TransitionToIA = string(policy.TransitionToIA)
+			}
+			if policy.TransitionToArchive != "" {
+				details.TransitionToArchive = string(policy.TransitionToArchive)
+			}
+		}
+	}
+
+	// One Zone file systems are billed at lower rates and do not support Archive
+	storagePrefix := ""
+	if details.AvailabilityZone != "" {
+		storagePrefix = "OneZone"
+	}
+	monthlyCost := pricing.EFSStorageMonthly(storagePrefix+"Standard", details.StandardBytes) +
+		pricing.EFSStorageMonthly(storagePrefix+"IA", details.IABytes) +
+		pricing.EFSStorageMonthly("Archive", details.ArchiveBytes)
+	if fileSystem.ThroughputMode == efstypes.ThroughputModeProvisioned {
+		monthlyCost += pricing.EFSProvisionedThroughputMonthly(details.ProvisionedThroughputInMibps)
+	}
+
+	var flags []models.Flag
+	if details.MountTargets == 0 {
+		flags = append(flags, models.Flag{
+			Code:    "no-mount-targets",
+			Message: "File system has no mount targets and cannot be accessed by any client",
+		})
+	}
+	if details.LifecycleKnown && details.TransitionToIA == "" {
+		flags = append(flags, models.Flag{
+			Code:    "no-ia-lifecycle",
+			Message: "No lifecycle policy moves infrequently accessed files to the Infrequent Access storage class",
+		})
+	}
+
+	tags := make([]models.Tag, 0, len(fileSystem.Tags))
+	for _, tag := range fileSystem.Tags {
+		tags = append(tags, models.Tag{Key: derefString(tag.Key), Value: derefString(tag.Value)})
+	}
+
+	return models.Resource{
+		ID:          id,
+		Name:        nameFromTags(tags, id),
+		Type:        models.EFSFileSystem,
+		Region:      c.Region,
+		Status:      string(fileSystem.LifeCycleState),
+		CreatedAt:   timeValue(fileSystem.CreationTime),
+		Details:     details,
+		DailyCost:   monthlyCost / 30,
+		MonthlyCost: monthlyCost,
+		Tags:        tags,
+		Flags:       flags,
+	}
+}
+
+// GetFSxFileSystems returns FSx file systems with their estimated monthly storage and throughput cost
+func (c *Client) GetFSxFileSystems(ctx context.Context) ([]models.Resource, error) {
+	var fileSystems []models.Resource
+
+	paginator := fsx.NewDescribeFileSystemsPaginator(c.FSxClient, &fsx.DescribeFileSystemsInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing FSx file systems: %v", err)
+			return nil, err
+		}
+
+		for _, fileSystem := range result.FileSystems {
+			fileSystems = append(fileSystems, c.newFSxFileSystemResource(fileSystem))
+		}
+	}
+
+	return fileSystems, nil
+}
+
+// newFSxFileSystemResource builds the resource for an FSx file system
+func (c *Client) newFSxFileSystemResource(fileSystem fsxtypes.FileSystem) models.Resource {
+	id := *fileSystem.FileSystemId
+	details := models.FSxFileSystemDetails{
+		ARN:               derefString(fileSystem.ResourceARN),
+		FileSystemType:    string(fileSystem.FileSystemType),
+		StorageType:       string(fileSystem.StorageType),
+		StorageCapacityGB: int32Value(fileSystem.StorageCapacity),
+		VpcID:             derefString(fileSystem.VpcId),
+	}
+
+	switch {
+	case fileSystem.WindowsConfiguration != nil:
+		details.DeploymentType = string(fileSystem.WindowsConfiguration.DeploymentType)
+		details.ThroughputCapacityMBps = int32Value(fileSystem.WindowsConfiguration.ThroughputCapacity)
+	case fileSystem.OntapConfiguration != nil:
+		details.DeploymentType = string(fileSystem.OntapConfiguration.DeploymentType)
+		details.ThroughputCapacityMBps = int32Value(fileSystem.OntapConfiguration.ThroughputCapacity)
+	case fileSystem.OpenZFSConfiguration != nil:
+		details.DeploymentType = string(fileSystem.OpenZFSConfiguration.DeploymentType)
+		details.ThroughputCapacityMBps = int32Value(fileSystem.OpenZFSConfiguration.ThroughputCapacity)
+	case fileSystem.LustreConfiguration != nil:
+		details.DeploymentType = string(fileSystem.LustreConfiguration.DeploymentType)
+		details.PerUnitStorageThroughput = int32Value(fileSystem.LustreConfiguration.PerUnitStorageThroughput)
+	}
+
+	var monthlyCost float64
+	if fileSystem.FileSystemType == fsxtypes.FileSystemTypeLustre {
+		monthlyCost, details.PriceKnown = pricing.FSxLustreMonthly(details.PerUnitStorageThroughput, details.StorageCapacityGB)
+	} else {
+		monthlyCost, details.PriceKnown = pricing.FSxMonthly(details.FileSystemType, details.StorageType,
+			strings.HasPrefix(details.DeploymentType, "MULTI_AZ"), details.StorageCapacityGB, details.ThroughputCapacityMBps)
+	}
+
+	var flags []models.Flag
+	if !details.PriceKnown {
+		flags = append(flags, models.Flag{
+			Code:    "unknown-fsx-price",
+			Message: "No list price is known for this file system configuration",
+		})
+	}
+
+	tags := make([]models.Tag, 0, len(fileSystem.Tags))
+	for _, tag := range fileSystem.Tags {
+		tags = append(tags, models.Tag{Key: derefString(tag.Key), Value: derefString(tag.Value)})
+	}
+
+	return models.Resource{
+		ID:          id,
+		Name:        nameFromTags(tags, id),
+		Type:        models.FSxFileSystem,
+		Region:      c.Region,
+		Status:      string(fileSystem.Lifecycle),
+		CreatedAt:   timeValue(fileSystem.CreationTime),
+		Details:     details,
+		DailyCost:   monthlyCost / 30,
+		MonthlyCost: monthlyCost,
+		Tags:        tags,
+		Flags:       flags,
+	}
+}
+
+func int64Value(i *int64) int64 {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// listKMSKeys returns customer-managed KMS keys, flagging disabled keys that are still billed.
+// AWS-managed keys carry no monthly fee and keys pending deletion are no longer billed.
+func (c *Client) listKMSKeys(ctx context.Context) ([]unitFeeItem, error) {
+	var items []unitFeeItem
+
+	paginator := kms.NewListKeysPaginator(c.KMSClient, &kms.ListKeysInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range result.Keys {
+			described, err := c.KMSClient.DescribeKey(ctx, &kms.DescribeKeyInput{KeyId: entry.KeyId})
+			if err != nil {
+				log.Printf("Error describing KMS key %s: %v", derefString(entry.KeyId), err)
+				continue
+			}
+			key := described.KeyMetadata
+			if key.KeyManager != kmstypes.KeyManagerTypeCustomer {
+				continue
+			}
+
+			units := 1.0
+			if key.KeyState == kmstypes.KeyStatePendingDeletion {
+				units = 0
+			}
+
+			var flags []models.Flag
+			if key.KeyState == kmstypes.KeyStateDisabled {
+				flags = append(flags, models.Flag{
+					Code:    "disabled-key",
+					Message: "Key is disabled but still billed monthly; schedule it for deletion if it is no longer needed",
+				})
+			}
+
+			items = append(items, unitFeeItem{
+				ID:        derefString(key.Arn),
+				Name:      c.kmsKeyName(ctx, *key.KeyId),
+				Status:    string(key.KeyState),
+				CreatedAt: timeValue(key.CreationDate),
+				Units:     units,
+				Attributes: map[string]string{
+					"keySpec":     string(key.KeySpec),
+					"keyUsage":    string(key.KeyUsage),
+					"multiRegion": strconv.FormatBool(key.MultiRegion != nil && *key.MultiRegion),
+				},
+				Tags:  c.kmsKeyTags(ctx, *key.KeyId),
+				Flags: flags,
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// kmsKeyName returns the first alias of a key, or the key ID when it has none
+func (c *Client) kmsKeyName(ctx context.Context, keyID string) string {
+	result, err := c.KMSClient.ListAliases(ctx, &kms.ListAliasesInput{KeyId: &keyID})
+	if err != nil {
+		log.Printf("Error listing aliases of KMS key %s: %v", keyID, err)
+		return keyID
+	}
+	for _, alias := range result.Aliases {
+		if alias.AliasName != nil {
+			return *alias.AliasName
+		}
+	}
+	return keyID
+}
+
+// kmsKeyTags returns the tags of a key
+func (c *Client) kmsKeyTags(ctx context.Context, keyID string) []models.Tag {
+	var tags []models.Tag
+	paginator := kms.NewListResourceTagsPaginator(c.KMSClient, &kms.ListResourceTagsInput{KeyId: &keyID})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error listing tags of KMS key %s: %v", keyID, err)
+			return tags
+		}
+		for _, tag := range result.Tags {
+			tags = append(tags, models.Tag{Key: derefString(tag.TagKey), Value: derefString(tag.TagValue)})
+		}
+	}
+	return tags
+}
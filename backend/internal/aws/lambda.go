@@ -0,0 +1,214 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+const (
+	// lambdaMetricsLookback is the window used to estimate a month of invocations
+	lambdaMetricsLookback = 30 * 24 * time.Hour
+
+	// overProvisionedMemoryRatio is the peak memory utilization below which
+	// a function is flagged as over-provisioned
+	overProvisionedMemoryRatio = 0.5
+
+	// minFlaggedMemoryMB keeps functions already at the minimum size from being flagged
+	minFlaggedMemoryMB = 256
+)
+
+// deprecatedLambdaRuntimes maps runtimes to the date AWS deprecated them
+var deprecatedLambdaRuntimes = map[string]string{
+	"python2.7":     "2021-07-15",
+	"python3.6":     "2022-07-18",
+	"python3.7":     "2023-12-04",
+	"python3.8":     "2024-10-14",
+	"python3.9":     "2025-12-15",
+	"nodejs":        "2016-10-31",
+	"nodejs4.3":     "2020-03-05",
+	"nodejs6.10":    "2019-08-12",
+	"nodejs8.10":    "2020-03-06",
+	"nodejs10.x":    "2021-07-30",
+	"nodejs12.x":    "2023-03-31",
+	"nodejs14.x":    "2023-12-04",
+	"nodejs16.x":    "2024-06-12",
+	"nodejs18.x":    "2025-09-01",
+	"nodejs20.x":    "2026-04-30",
+	"java8":         "2024-01-08",
+	"go1.x":         "2024-01-08",
+	"provided":      "2024-01-08",
+	"ruby2.5":       "2021-07-30",
+	"ruby2.7":       "2023-12-07",
+	"ruby3.2":       "2026-03-31",
+	"dotnetcore1.0": "2019-07-30",
+	"dotnetcore2.0": "2019-05-30",
+	"dotnetcore2.1": "2022-01-05",
+	"dotnetcore3.1": "2023-04-03",
+	"dotnet5.0":     "2022-05-10",
+	"dotnet6":       "2024-12-20",
+	"dotnet7":       "2024-05-14",
+}
+
+// GetLambdaFunctions returns all Lambda functions with invocation-based monthly cost estimates
+func (c *Client) GetLambdaFunctions(ctx context.Context) ([]models.Resource, error) {
+	var functions []models.Resource
+
+	paginator := lambda.NewListFunctionsPaginator(c.LambdaClient, &lambda.ListFunctionsInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error listing Lambda functions: %v", err)
+			return nil, err
+		}
+
+		for _, fn := range result.Functions {
+			functions = append(functions, c.describeLambdaFunction(ctx, fn))
+		}
+	}
+
+	return functions, nil
+}
+
+// describeLambdaFunction converts a function configuration into a resource,
+// adding its tags and CloudWatch usage metrics
+func (c *Client) describeLambdaFunction(ctx context.Context, fn lambdatypes.FunctionConfiguration) models.Resource {
+	name := *fn.FunctionName
+
+	details := models.LambdaFunctionDetails{
+		ARN:          *fn.FunctionArn,
+		Runtime:      string(fn.Runtime),
+		PackageType:  string(fn.PackageType),
+		Architecture: "x86_64",
+	}
+	if fn.MemorySize != nil {
+		details.MemorySizeMB = *fn.MemorySize
+	}
+	if fn.Timeout != nil {
+		details.TimeoutSeconds = *fn.Timeout
+	}
+	if len(fn.Architectures) > 0 {
+		details.Architecture = string(fn.Architectures[0])
+	}
+
+	var tags []models.Tag
+	tagResult, err := c.LambdaClient.ListTags(ctx, &lambda.ListTagsInput{Resource: fn.FunctionArn})
+	if err != nil {
+		log.Printf("Error listing tags for Lambda function %s: %v", name, err)
+	} else {
+		tags = tagsFromMap(tagResult.Tags)
+	}
+
+	if err := c.measureLambdaUsage(ctx, name, &details); err != nil {
+		log.Printf("Error reading metrics for Lambda function %s: %v", name, err)
+	}
+
+	monthlyCost := pricing.LambdaCost(details.Architecture, details.MonthlyInvocations, details.MonthlyGBSeconds)
+
+	status := string(fn.State)
+	if status == "" {
+		status = "Active"
+	}
+
+	// Lambda does not expose a creation date, so the last modification time is used
+	createdAt := time.Time{}
+	if fn.LastModified != nil {
+		createdAt, _ = time.Parse("2006-01-02T15:04:05.000-0700", *fn.LastModified)
+	}
+
+	return models.Resource{
+		ID:          details.ARN,
+		Name:        name,
+		Type:        models.LambdaFunction,
+		Region:      c.Region,
+		Status:      status,
+		CreatedAt:   createdAt,
+		Details:     details,
+		DailyCost:   monthlyCost / 30,
+		MonthlyCost: monthlyCost,
+		Tags:        tags,
+		Flags:       lambdaFunctionFlags(details, time.Now()),
+	}
+}
+
+// measureLambdaUsage fills in invocation, duration and memory metrics for the last 30 days.
+// Peak memory utilization is only available when Lambda Insights is enabled on the function.
+func (c *Client) measureLambdaUsage(ctx context.Context, name string, details *models.LambdaFunctionDetails) error {
+	query := metricQuery{
+		Namespace:  "AWS/Lambda",
+		Dimensions: map[string]string{"FunctionName": name},
+		Statistic:  types.StatisticSum,
+		Period:     24 * time.Hour,
+		Lookback:   lambdaMetricsLookback,
+	}
+
+	query.MetricName = "Invocations"
+	invocations, err := c.sumMetricValues(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	query.MetricName = "Duration"
+	durationMs, err := c.sumMetricValues(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	details.MonthlyInvocations = invocations
+	if invocations > 0 {
+		details.AverageDurationMs = durationMs / invocations
+	}
+	details.MonthlyGBSeconds = durationMs / 1000 * float64(details.MemorySizeMB) / 1024
+
+	datapoints, err := c.getMetricDatapoints(ctx, metricQuery{
+		Namespace:  "LambdaInsights",
+		MetricName: "memory_utilization",
+		Dimensions: map[string]string{"function_name": name},
+		Statistic:  types.StatisticMaximum,
+		Period:     24 * time.Hour,
+		Lookback:   lambdaMetricsLookback,
+	})
+	if err != nil {
+		return err
+	}
+	for _, dp := range datapoints {
+		value := datapointValue(dp, types.StatisticMaximum) / 100
+		if value > details.PeakMemoryUtilization {
+			details.PeakMemoryUtilization = value
+		}
+		details.MemoryUtilizationKnown = true
+	}
+
+	return nil
+}
+
+// lambdaFunctionFlags returns the review flags for a function
+func lambdaFunctionFlags(details models.LambdaFunctionDetails, now time.Time) []models.Flag {
+	var flags []models.Flag
+
+	if date, ok := deprecatedLambdaRuntimes[details.Runtime]; ok && date <= now.Format("2006-01-02") {
+		flags = append(flags, models.Flag{
+			Code:    "deprecated-runtime",
+			Message: fmt.Sprintf("Runtime %s was deprecated on %s", details.Runtime, date),
+		})
+	}
+
+	if details.MemoryUtilizationKnown && details.MemorySizeMB >= minFlaggedMemoryMB &&
+		details.PeakMemoryUtilization < overProvisionedMemoryRatio {
+		flags = append(flags, models.Flag{
+			Code: "over-provisioned-memory",
+			Message: fmt.Sprintf("Peak memory use is %.0f%% of the %d MB configured",
+				details.PeakMemoryUtilization*100, details.MemorySizeMB),
+		})
+	}
+
+	return flags
+}
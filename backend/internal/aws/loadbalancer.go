@@ -0,0 +1,336 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+// networkMetricsLookback is the window used to estimate a month of network usage
+const networkMetricsLookback = 30 * 24 * time.Hour
+
+// maxTagDescribeBatch is the number of load balancers whose tags can be described in one call
+const maxTagDescribeBatch = 20
+
+// elbv2Namespaces maps load balancer types to their CloudWatch namespace
+var elbv2Namespaces = map[string]string{
+	"application": "AWS/ApplicationELB",
+	"network":     "AWS/NetworkELB",
+	"gateway":     "AWS/GatewayELB",
+}
+
+// GetLoadBalancers returns all application, network, gateway and classic load balancers
+// with estimated hourly and usage costs
+func (c *Client) GetLoadBalancers(ctx context.Context) ([]models.Resource, error) {
+	v2Balancers, err := c.getV2LoadBalancers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	classicBalancers, err := c.getClassicLoadBalancers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(v2Balancers, classicBalancers...), nil
+}
+
+// getV2LoadBalancers returns application, network and gateway load balancers
+func (c *Client) getV2LoadBalancers(ctx context.Context) ([]models.Resource, error) {
+	var balancers []elbv2types.LoadBalancer
+	paginator := elbv2.NewDescribeLoadBalancersPaginator(c.ELBv2Client, &elbv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing load balancers: %v", err)
+			return nil, err
+		}
+		balancers = append(balancers, result.LoadBalancers...)
+	}
+
+	arns := make([]string, 0, len(balancers))
+	for _, lb := range balancers {
+		arns = append(arns, *lb.LoadBalancerArn)
+	}
+	tagsByARN, err := c.getV2LoadBalancerTags(ctx, arns)
+	if err != nil {
+		log.Printf("Error describing load balancer tags: %v", err)
+	}
+
+	var resources []models.Resource
+	for _, lb := range balancers {
+		arn := *lb.LoadBalancerArn
+		lbType := string(lb.Type)
+
+		details := models.LoadBalancerDetails{
+			ARN:              arn,
+			LoadBalancerType: lbType,
+			Scheme:           string(lb.Scheme),
+		}
+		if lb.DNSName != nil {
+			details.DNSName = *lb.DNSName
+		}
+		if lb.VpcId != nil {
+			details.VPCID = *lb.VpcId
+		}
+
+		if err := c.countV2Targets(ctx, arn, &details); err != nil {
+			log.Printf("Error describing targets of load balancer %s: %v", *lb.LoadBalancerName, err)
+		} else {
+			details.TargetHealthKnown = true
+		}
+		if err := c.measureV2LoadBalancerUsage(ctx, arn, &details); err != nil {
+			log.Printf("Error reading metrics for load balancer %s: %v", *lb.LoadBalancerName, err)
+		}
+
+		details.HourlyMonthlyCost = pricing.LoadBalancerHourlyMonthly(lbType)
+		details.UsageMonthlyCost = pricing.LoadBalancerUsageMonthly(lbType, details.MonthlyCapacityUnits)
+
+		status := ""
+		if lb.State != nil {
+			status = string(lb.State.Code)
+		}
+
+		resources = append(resources, newLoadBalancerResource(arn, *lb.LoadBalancerName, status, c.Region,
+			timeValue(lb.CreatedTime), details, tagsByARN[arn]))
+	}
+
+	return resources, nil
+}
+
+// getV2LoadBalancerTags returns the tags of the given load balancers keyed by ARN
+func (c *Client) getV2LoadBalancerTags(ctx context.Context, arns []string) (map[string][]models.Tag, error) {
+	tags := make(map[string][]models.Tag)
+	for start := 0; start < len(arns); start += maxTagDescribeBatch {
+		end := min(start+maxTagDescribeBatch, len(arns))
+		result, err := c.ELBv2Client.DescribeTags(ctx, &elbv2.DescribeTagsInput{ResourceArns: arns[start:end]})
+		if err != nil {
+			return tags, err
+		}
+		for _, description := range result.TagDescriptions {
+			for _, tag := range description.Tags {
+				tags[*description.ResourceArn] = append(tags[*description.ResourceArn],
+					models.Tag{Key: *tag.Key, Value: *tag.Value})
+			}
+		}
+	}
+	return tags, nil
+}
+
+// countV2Targets counts the registered and healthy targets across a load balancer's target groups
+func (c *Client) countV2Targets(ctx context.Context, arn string, details *models.LoadBalancerDetails) error {
+	paginator := elbv2.NewDescribeTargetGroupsPaginator(c.ELBv2Client, &elbv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: &arn,
+	})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, group := range result.TargetGroups {
+			details.TargetGroupCount++
+			health, err := c.ELBv2Client.DescribeTargetHealth(ctx, &elbv2.DescribeTargetHealthInput{
+				TargetGroupArn: group.TargetGroupArn,
+			})
+			if err != nil {
+				return err
+			}
+			for _, target := range health.TargetHealthDescriptions {
+				details.TotalTargets++
+				if target.TargetHealth != nil && target.TargetHealth.State == elbv2types.TargetHealthStateEnumHealthy {
+					details.HealthyTargets++
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// measureV2LoadBalancerUsage fills in the capacity units and bytes processed over the last 30 days
+func (c *Client) measureV2LoadBalancerUsage(ctx context.Context, arn string, details *models.LoadBalancerDetails) error {
+	namespace, ok := elbv2Namespaces[details.LoadBalancerType]
+	if !ok {
+		return nil
+	}
+
+	// Metrics are keyed by the ARN suffix, e.g. "app/my-lb/50dc6c495c0c9188"
+	dimension := arn
+	if idx := strings.Index(arn, ":loadbalancer/"); idx >= 0 {
+		dimension = arn[idx+len(":loadbalancer/"):]
+	}
+
+	// Summing hourly averages of ConsumedLCUs yields capacity-unit hours
+	capacityUnits, err := c.sumMetricValues(ctx, metricQuery{
+		Namespace:  namespace,
+		MetricName: "ConsumedLCUs",
+		Dimensions: map[string]string{"LoadBalancer": dimension},
+		Statistic:  types.StatisticAverage,
+		Period:     time.Hour,
+		Lookback:   networkMetricsLookback,
+	})
+	if err != nil {
+		return err
+	}
+	details.MonthlyCapacityUnits = capacityUnits
+
+	processedBytes, err := c.sumMetricValues(ctx, metricQuery{
+		Namespace:  namespace,
+		MetricName: "ProcessedBytes",
+		Dimensions: map[string]string{"LoadBalancer": dimension},
+		Statistic:  types.StatisticSum,
+		Period:     24 * time.Hour,
+		Lookback:   networkMetricsLookback,
+	})
+	if err != nil {
+		return err
+	}
+	details.MonthlyProcessedBytes = processedBytes
+
+	return nil
+}
+
+// getClassicLoadBalancers returns classic load balancers
+func (c *Client) getClassicLoadBalancers(ctx context.Context) ([]models.Resource, error) {
+	var balancers []elbtypes.LoadBalancerDescription
+	paginator := elb.NewDescribeLoadBalancersPaginator(c.ELBClient, &elb.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing classic load balancers: %v", err)
+			return nil, err
+		}
+		balancers = append(balancers, result.LoadBalancerDescriptions...)
+	}
+
+	names := make([]string, 0, len(balancers))
+	for _, lb := range balancers {
+		names = append(names, *lb.LoadBalancerName)
+	}
+	tagsByName, err := c.getClassicLoadBalancerTags(ctx, names)
+	if err != nil {
+		log.Printf("Error describing classic load balancer tags: %v", err)
+	}
+
+	var resources []models.Resource
+	for _, lb := range balancers {
+		name := *lb.LoadBalancerName
+
+		details := models.LoadBalancerDetails{
+			LoadBalancerType: "classic",
+		}
+		if lb.Scheme != nil {
+			details.Scheme = *lb.Scheme
+		}
+		if lb.DNSName != nil {
+			details.DNSName = *lb.DNSName
+		}
+		if lb.VPCId != nil {
+			details.VPCID = *lb.VPCId
+		}
+
+		health, err := c.ELBClient.DescribeInstanceHealth(ctx, &elb.DescribeInstanceHealthInput{LoadBalancerName: &name})
+		if err != nil {
+			log.Printf("Error describing instance health of classic load balancer %s: %v", name, err)
+		} else {
+			details.TargetHealthKnown = true
+			for _, state := range health.InstanceStates {
+				details.TotalTargets++
+				if state.State != nil && *state.State == "InService" {
+					details.HealthyTargets++
+				}
+			}
+		}
+
+		processedBytes, err := c.sumMetricValues(ctx, metricQuery{
+			Namespace:  "AWS/ELB",
+			MetricName: "EstimatedProcessedBytes",
+			Dimensions: map[string]string{"LoadBalancerName": name},
+			Statistic:  types.StatisticSum,
+			Period:     24 * time.Hour,
+			Lookback:   networkMetricsLookback,
+		})
+		if err != nil {
+			log.Printf("Error reading metrics for classic load balancer %s: %v", name, err)
+		}
+		details.MonthlyProcessedBytes = processedBytes
+
+		details.HourlyMonthlyCost = pricing.LoadBalancerHourlyMonthly("classic")
+		details.UsageMonthlyCost = pricing.LoadBalancerUsageMonthly("classic", processedBytes/pricing.BytesPerGB)
+
+		resources = append(resources, newLoadBalancerResource(name, name, "active", c.Region,
+			timeValue(lb.CreatedTime), details, tagsByName[name]))
+	}
+
+	return resources, nil
+}
+
+// getClassicLoadBalancerTags returns the tags of the given classic load balancers keyed by name
+func (c *Client) getClassicLoadBalancerTags(ctx context.Context, names []string) (map[string][]models.Tag, error) {
+	tags := make(map[string][]models.Tag)
+	for start := 0; start < len(names); start += maxTagDescribeBatch {
+		end := min(start+maxTagDescribeBatch, len(names))
+		result, err := c.ELBClient.DescribeTags(ctx, &elb.DescribeTagsInput{LoadBalancerNames: names[start:end]})
+		if err != nil {
+			return tags, err
+		}
+		for _, description := range result.TagDescriptions {
+			for _, tag := range description.Tags {
+				value := ""
+				if tag.Value != nil {
+					value = *tag.Value
+				}
+				tags[*description.LoadBalancerName] = append(tags[*description.LoadBalancerName],
+					models.Tag{Key: *tag.Key, Value: value})
+			}
+		}
+	}
+	return tags, nil
+}
+
+// newLoadBalancerResource builds a load balancer resource and its review flags
+func newLoadBalancerResource(id, name, status, region string, createdAt time.Time, details models.LoadBalancerDetails, tags []models.Tag) models.Resource {
+	monthlyCost := details.HourlyMonthlyCost + details.UsageMonthlyCost
+
+	var flags []models.Flag
+	// Target health that could not be described is unknown, not unhealthy
+	if details.TargetHealthKnown && details.HealthyTargets == 0 {
+		flags = append(flags, models.Flag{
+			Code:    "no-healthy-targets",
+			Message: fmt.Sprintf("Load balancer has no healthy targets (%d registered)", details.TotalTargets),
+		})
+	}
+
+	return models.Resource{
+		ID:          id,
+		Name:        name,
+		Type:        models.LoadBalancer,
+		Region:      region,
+		Status:      status,
+		CreatedAt:   createdAt,
+		Details:     details,
+		DailyCost:   monthlyCost / 30,
+		MonthlyCost: monthlyCost,
+		Tags:        tags,
+		Flags:       flags,
+	}
+}
+
+// timeValue dereferences an optional time, returning the zero time when it is nil
+func timeValue(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
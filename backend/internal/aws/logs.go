@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+// GetLogGroups returns CloudWatch Logs log groups with their stored bytes and estimated monthly
+// storage cost, flagging groups that keep their events forever
+func (c *Client) GetLogGroups(ctx context.Context) ([]models.Resource, error) {
+	var logGroups []models.Resource
+
+	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(c.CloudWatchLogsClient, &cloudwatchlogs.DescribeLogGroupsInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing CloudWatch log groups: %v", err)
+			return nil, err
+		}
+
+		for _, logGroup := range result.LogGroups {
+			logGroups = append(logGroups, c.newLogGroupResource(logGroup))
+		}
+	}
+
+	return logGroups, nil
+}
+
+// newLogGroupResource builds the resource for a log group
+func (c *Client) newLogGroupResource(logGroup logstypes.LogGroup) models.Resource {
+	name := derefString(logGroup.LogGroupName)
+	details := models.LogGroupDetails{
+		ARN:               derefString(logGroup.Arn),
+		StoredBytes:       int64Value(logGroup.StoredBytes),
+		RetentionDays:     int32Value(logGroup.RetentionInDays),
+		MetricFilterCount: int32Value(logGroup.MetricFilterCount),
+		LogGroupClass:     string(logGroup.LogGroupClass),
+	}
+
+	var flags []models.Flag
+	if details.RetentionDays == 0 {
+		flags = append(flags, models.Flag{
+			Code:    "no-log-retention",
+			Message: "Log events never expire, so stored bytes and their cost keep growing",
+		})
+	}
+
+	var createdAt time.Time
+	if logGroup.CreationTime != nil {
+		createdAt = time.UnixMilli(*logGroup.CreationTime)
+	}
+	monthlyCost := pricing.LogStorageMonthly(details.StoredBytes)
+
+	return models.Resource{
+		ID:          name,
+		Name:        name,
+		Type:        models.CloudWatchLogGroup,
+		Region:      c.Region,
+		Status:      "active",
+		CreatedAt:   createdAt,
+		Details:     details,
+		DailyCost:   monthlyCost / 30,
+		MonthlyCost: monthlyCost,
+		Tags:        make([]models.Tag, 0),
+		Flags:       flags,
+	}
+}
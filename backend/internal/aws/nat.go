@@ -0,0 +1,121 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+// negligibleNATTrafficBytes is the monthly traffic below which a NAT gateway is flagged
+const negligibleNATTrafficBytes = 1 << 30
+
+// GetNATGateways returns all available NAT gateways with estimated hourly and data processing costs
+func (c *Client) GetNATGateways(ctx context.Context) ([]models.Resource, error) {
+	input := &ec2.DescribeNatGatewaysInput{
+		Filter: []ec2types.Filter{
+			{
+				Name:   stringPtr("state"),
+				Values: []string{string(ec2types.NatGatewayStateAvailable)},
+			},
+		},
+	}
+
+	var gateways []models.Resource
+	paginator := ec2.NewDescribeNatGatewaysPaginator(c.EC2Client, input)
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing NAT gateways: %v", err)
+			return nil, err
+		}
+
+		for _, gateway := range result.NatGateways {
+			gateways = append(gateways, c.describeNATGateway(ctx, gateway))
+		}
+	}
+
+	return gateways, nil
+}
+
+// describeNATGateway converts a NAT gateway into a resource, adding its processed traffic
+func (c *Client) describeNATGateway(ctx context.Context, gateway ec2types.NatGateway) models.Resource {
+	id := *gateway.NatGatewayId
+
+	details := models.NATGatewayDetails{
+		ConnectivityType: string(gateway.ConnectivityType),
+		PublicIPs:        make([]string, 0),
+	}
+	if gateway.VpcId != nil {
+		details.VPCID = *gateway.VpcId
+	}
+	if gateway.SubnetId != nil {
+		details.SubnetID = *gateway.SubnetId
+	}
+	for _, address := range gateway.NatGatewayAddresses {
+		if address.PublicIp != nil {
+			details.PublicIPs = append(details.PublicIPs, *address.PublicIp)
+		}
+	}
+
+	// Data processing is billed on traffic in both directions through the gateway. Without both
+	// directions the traffic and its cost are left unknown rather than counted as zero.
+	details.TrafficKnown = true
+	for _, metric := range []string{"BytesOutToDestination", "BytesInFromDestination"} {
+		bytes, err := c.sumMetricValues(ctx, metricQuery{
+			Namespace:  "AWS/NATGateway",
+			MetricName: metric,
+			Dimensions: map[string]string{"NatGatewayId": id},
+			Statistic:  types.StatisticSum,
+			Period:     24 * time.Hour,
+			Lookback:   networkMetricsLookback,
+		})
+		if err != nil {
+			log.Printf("Error reading %s for NAT gateway %s: %v", metric, id, err)
+			details.TrafficKnown = false
+			break
+		}
+		details.MonthlyProcessedBytes += bytes
+	}
+	if !details.TrafficKnown {
+		details.MonthlyProcessedBytes = 0
+	}
+
+	details.HourlyMonthlyCost = pricing.NATGatewayHourlyMonthly()
+	if details.TrafficKnown {
+		details.DataMonthlyCost = pricing.NATGatewayDataMonthly(details.MonthlyProcessedBytes)
+	}
+	monthlyCost := details.HourlyMonthlyCost + details.DataMonthlyCost
+
+	var flags []models.Flag
+	if details.TrafficKnown && details.MonthlyProcessedBytes < negligibleNATTrafficBytes {
+		flags = append(flags, models.Flag{
+			Code: "negligible-traffic",
+			Message: fmt.Sprintf("NAT gateway processed only %.2f GB in the last 30 days",
+				details.MonthlyProcessedBytes/pricing.BytesPerGB),
+		})
+	}
+
+	tags := tagsFromEC2(gateway.Tags)
+
+	return models.Resource{
+		ID:          id,
+		Name:        nameFromTags(tags, id),
+		Type:        models.NATGateway,
+		Region:      c.Region,
+		Status:      string(gateway.State),
+		CreatedAt:   timeValue(gateway.CreateTime),
+		Details:     details,
+		DailyCost:   monthlyCost / 30,
+		MonthlyCost: monthlyCost,
+		Tags:        tags,
+		Flags:       flags,
+	}
+}
@@ -0,0 +1,66 @@
+package aws
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// listHostedZones returns Route 53 hosted zones. Zones are billed at the rate of the first
+// 25 zones in an account; the lower rate for additional zones is not applied.
+func (c *Client) listHostedZones(ctx context.Context) ([]unitFeeItem, error) {
+	var items []unitFeeItem
+
+	paginator := route53.NewListHostedZonesPaginator(c.Route53Client, &route53.ListHostedZonesInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, zone := range result.HostedZones {
+			id := strings.TrimPrefix(*zone.Id, "/hostedzone/")
+			private := zone.Config != nil && zone.Config.PrivateZone
+
+			items = append(items, unitFeeItem{
+				ID:     id,
+				Name:   strings.TrimSuffix(derefString(zone.Name), "."),
+				Status: "active",
+				Units:  1,
+				Attributes: map[string]string{
+					"privateZone": strconv.FormatBool(private),
+					"recordSets":  strconv.FormatInt(int64Value(zone.ResourceRecordSetCount), 10),
+				},
+				Tags: c.hostedZoneTags(ctx, id),
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// hostedZoneTags returns the tags of a hosted zone
+func (c *Client) hostedZoneTags(ctx context.Context, id string) []models.Tag {
+	result, err := c.Route53Client.ListTagsForResource(ctx, &route53.ListTagsForResourceInput{
+		ResourceId:   &id,
+		ResourceType: route53types.TagResourceTypeHostedzone,
+	})
+	if err != nil {
+		log.Printf("Error listing tags of hosted zone %s: %v", id, err)
+		return nil
+	}
+
+	var tags []models.Tag
+	if result.ResourceTagSet != nil {
+		for _, tag := range result.ResourceTagSet.Tags {
+			tags = append(tags, models.Tag{Key: derefString(tag.Key), Value: derefString(tag.Value)})
+		}
+	}
+	return tags
+}
@@ -0,0 +1,226 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+const (
+	// maxVersionListPages caps how many ListObjectVersions pages are read per bucket
+	// when measuring noncurrent versions, to keep refreshes bounded on huge buckets
+	maxVersionListPages = 20
+
+	// noncurrentFlagRatio is the share of a bucket's size held by noncurrent versions
+	// above which the bucket is flagged
+	noncurrentFlagRatio = 0.25
+
+	// noncurrentFlagMinBytes keeps small buckets from being flagged for noncurrent versions
+	noncurrentFlagMinBytes = 1 << 30
+)
+
+// GetS3Buckets returns all S3 buckets with their storage size and estimated cost
+func (c *Client) GetS3Buckets(ctx context.Context) ([]models.Resource, error) {
+	result, err := c.S3Client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		log.Printf("Error listing S3 buckets: %v", err)
+		return nil, err
+	}
+
+	var buckets []models.Resource
+	for _, bucket := range result.Buckets {
+		resource, err := c.describeS3Bucket(ctx, bucket)
+		if err != nil {
+			log.Printf("Error describing S3 bucket %s: %v", *bucket.Name, err)
+			continue
+		}
+		buckets = append(buckets, resource)
+	}
+
+	return buckets, nil
+}
+
+// describeS3Bucket gathers the region, tags, versioning, lifecycle and size of a bucket
+func (c *Client) describeS3Bucket(ctx context.Context, bucket s3types.Bucket) (models.Resource, error) {
+	name := *bucket.Name
+
+	location, err := c.S3Client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: &name})
+	if err != nil {
+		return models.Resource{}, err
+	}
+	region := bucketRegion(location.LocationConstraint)
+	inRegion := func(o *s3.Options) { o.Region = region }
+
+	details := models.S3BucketDetails{
+		SizeByStorageClass: make(map[string]int64),
+	}
+
+	versioning, err := c.S3Client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: &name}, inRegion)
+	if err != nil {
+		return models.Resource{}, err
+	}
+	details.VersioningStatus = string(versioning.Status)
+	if details.VersioningStatus == "" {
+		details.VersioningStatus = "Disabled"
+	}
+
+	_, err = c.S3Client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: &name}, inRegion)
+	if err != nil && !isAPIError(err, "NoSuchLifecycleConfiguration") {
+		return models.Resource{}, err
+	}
+	details.HasLifecyclePolicy = err == nil
+
+	var tags []models.Tag
+	tagging, err := c.S3Client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: &name}, inRegion)
+	if err != nil && !isAPIError(err, "NoSuchTagSet") {
+		return models.Resource{}, err
+	}
+	if err == nil {
+		for _, tag := range tagging.TagSet {
+			tags = append(tags, models.Tag{Key: *tag.Key, Value: *tag.Value})
+		}
+	}
+
+	monthlyCost, err := c.measureBucketSize(ctx, name, region, &details)
+	if err != nil {
+		log.Printf("Error reading storage metrics for S3 bucket %s: %v", name, err)
+	}
+
+	if versioning.Status != "" {
+		details.NoncurrentVersionBytes, err = c.noncurrentVersionBytes(ctx, name, region)
+		if err != nil {
+			log.Printf("Error listing object versions for S3 bucket %s: %v", name, err)
+		}
+	}
+
+	createdAt := time.Time{}
+	if bucket.CreationDate != nil {
+		createdAt = *bucket.CreationDate
+	}
+
+	return models.Resource{
+		ID:          name,
+		Name:        name,
+		Type:        models.S3Bucket,
+		Region:      region,
+		Status:      "active",
+		CreatedAt:   createdAt,
+		Details:     details,
+		DailyCost:   monthlyCost / 30,
+		MonthlyCost: monthlyCost,
+		Tags:        tags,
+		Flags:       s3BucketFlags(details),
+	}, nil
+}
+
+// measureBucketSize fills in the bucket size per storage class from the daily
+// BucketSizeBytes metric and returns the estimated monthly storage cost
+func (c *Client) measureBucketSize(ctx context.Context, bucket, region string, details *models.S3BucketDetails) (float64, error) {
+	storageTypes, err := c.listMetricDimensionValues(ctx, "AWS/S3", "BucketSizeBytes",
+		map[string]string{"BucketName": bucket}, "StorageType", region)
+	if err != nil {
+		return 0, err
+	}
+
+	monthlyCost := 0.0
+	for _, storageType := range storageTypes {
+		size, err := c.latestMetricValue(ctx, metricQuery{
+			Namespace:  "AWS/S3",
+			MetricName: "BucketSizeBytes",
+			Dimensions: map[string]string{"BucketName": bucket, "StorageType": storageType},
+			Statistic:  types.StatisticAverage,
+			Period:     24 * time.Hour,
+			Lookback:   3 * 24 * time.Hour,
+			Region:     region,
+		})
+		if err != nil {
+			return monthlyCost, err
+		}
+
+		bytes := int64(size)
+		details.SizeByStorageClass[storageType] = bytes
+		details.SizeBytes += bytes
+		monthlyCost += pricing.S3StorageMonthly(storageType, bytes)
+	}
+
+	return monthlyCost, nil
+}
+
+// noncurrentVersionBytes sums the size of noncurrent object versions in a bucket,
+// reading at most maxVersionListPages pages
+func (c *Client) noncurrentVersionBytes(ctx context.Context, bucket, region string) (int64, error) {
+	input := &s3.ListObjectVersionsInput{Bucket: &bucket}
+	total := int64(0)
+
+	for page := 0; page < maxVersionListPages; page++ {
+		result, err := c.S3Client.ListObjectVersions(ctx, input, func(o *s3.Options) { o.Region = region })
+		if err != nil {
+			return total, err
+		}
+
+		for _, version := range result.Versions {
+			if version.IsLatest != nil && !*version.IsLatest && version.Size != nil {
+				total += *version.Size
+			}
+		}
+
+		if result.IsTruncated == nil || !*result.IsTruncated {
+			break
+		}
+		input.KeyMarker = result.NextKeyMarker
+		input.VersionIdMarker = result.NextVersionIdMarker
+	}
+
+	return total, nil
+}
+
+// s3BucketFlags returns the review flags for a bucket
+func s3BucketFlags(details models.S3BucketDetails) []models.Flag {
+	var flags []models.Flag
+
+	if !details.HasLifecyclePolicy {
+		flags = append(flags, models.Flag{
+			Code:    "no-lifecycle-policy",
+			Message: "Bucket has no lifecycle policy to transition or expire objects",
+		})
+	}
+
+	if details.NoncurrentVersionBytes >= noncurrentFlagMinBytes &&
+		float64(details.NoncurrentVersionBytes) >= noncurrentFlagRatio*float64(details.SizeBytes) {
+		flags = append(flags, models.Flag{
+			Code: "large-noncurrent-versions",
+			Message: fmt.Sprintf("Noncurrent object versions hold %.1f GB",
+				float64(details.NoncurrentVersionBytes)/pricing.BytesPerGB),
+		})
+	}
+
+	return flags
+}
+
+// bucketRegion converts a bucket location constraint into a region name
+func bucketRegion(constraint s3types.BucketLocationConstraint) string {
+	switch constraint {
+	case "":
+		return "us-east-1"
+	case s3types.BucketLocationConstraintEu:
+		return "eu-west-1"
+	default:
+		return string(constraint)
+	}
+}
+
+// isAPIError reports whether err is an AWS API error with the given code
+func isAPIError(err error, code string) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == code
+}
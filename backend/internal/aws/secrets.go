@@ -0,0 +1,76 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// DefaultUnusedSecretDays is the number of days without access after which a secret is flagged
+// as unused, unless the client sets UnusedSecretDays
+const DefaultUnusedSecretDays = 90
+
+// listSecrets returns Secrets Manager secrets, flagging those not accessed recently
+func (c *Client) listSecrets(ctx context.Context) ([]unitFeeItem, error) {
+	var items []unitFeeItem
+	unusedDays := c.UnusedSecretDays
+	if unusedDays <= 0 {
+		unusedDays = DefaultUnusedSecretDays
+	}
+	cutoff := time.Now().AddDate(0, 0, -unusedDays)
+
+	paginator := secretsmanager.NewListSecretsPaginator(c.SecretsManagerClient, &secretsmanager.ListSecretsInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, secret := range result.SecretList {
+			createdAt := timeValue(secret.CreatedDate)
+			lastAccessed := timeValue(secret.LastAccessedDate)
+			attributes := map[string]string{
+				"rotationEnabled": strconv.FormatBool(secret.RotationEnabled != nil && *secret.RotationEnabled),
+			}
+			if !lastAccessed.IsZero() {
+				attributes["lastAccessedDate"] = lastAccessed.Format("2006-01-02")
+			}
+
+			var flags []models.Flag
+			if lastAccessed.IsZero() && createdAt.Before(cutoff) {
+				flags = append(flags, models.Flag{
+					Code:    "unused-secret",
+					Message: fmt.Sprintf("Secret has never been accessed and is older than %d days", unusedDays),
+				})
+			} else if !lastAccessed.IsZero() && lastAccessed.Before(cutoff) {
+				flags = append(flags, models.Flag{
+					Code:    "unused-secret",
+					Message: fmt.Sprintf("Secret has not been accessed in %d days", int(time.Since(lastAccessed).Hours()/24)),
+				})
+			}
+
+			tags := make([]models.Tag, 0, len(secret.Tags))
+			for _, tag := range secret.Tags {
+				tags = append(tags, models.Tag{Key: derefString(tag.Key), Value: derefString(tag.Value)})
+			}
+
+			items = append(items, unitFeeItem{
+				ID:         derefString(secret.ARN),
+				Name:       derefString(secret.Name),
+				Status:     "active",
+				CreatedAt:  createdAt,
+				Units:      1,
+				Attributes: attributes,
+				Tags:       tags,
+				Flags:      flags,
+			})
+		}
+	}
+
+	return items, nil
+}
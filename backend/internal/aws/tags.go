@@ -0,0 +1,40 @@
+package aws
+
+import (
+	"sort"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// tagsFromMap converts a tag map into resource tags sorted by key
+func tagsFromMap(tagMap map[string]string) []models.Tag {
+	tags := make([]models.Tag, 0, len(tagMap))
+	for key, value := range tagMap {
+		tags = append(tags, models.Tag{Key: key, Value: value})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	return tags
+}
+
+// nameFromTags returns the value of the Name tag, or fallback when there is none
+func nameFromTags(tags []models.Tag, fallback string) string {
+	for _, tag := range tags {
+		if tag.Key == "Name" && tag.Value != "" {
+			return tag.Value
+		}
+	}
+	return fallback
+}
+
+// tagsFromEC2 converts EC2 tags into resource tags
+func tagsFromEC2(ec2Tags []ec2types.Tag) []models.Tag {
+	tags := make([]models.Tag, 0, len(ec2Tags))
+	for _, tag := range ec2Tags {
+		if tag.Key != nil && tag.Value != nil {
+			tags = append(tags, models.Tag{Key: *tag.Key, Value: *tag.Value})
+		}
+	}
+	return tags
+}
@@ -0,0 +1,88 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+// unitFeeItem is a billable item found by a unit fee collector
+type unitFeeItem struct {
+	ID         string
+	Name       string
+	Status     string
+	CreatedAt  time.Time
+	Unit       string // overrides the collector's unit when set
+	Units      float64
+	Attributes map[string]string
+	Tags       []models.Tag
+	Flags      []models.Flag
+}
+
+// unitFeeCollector lists the items of a service that are billed a flat monthly fee per unit
+type unitFeeCollector struct {
+	Name         string
+	Service      string
+	Unit         string
+	ResourceType models.ResourceType
+	Global       bool
+	List         func(c *Client, ctx context.Context) ([]unitFeeItem, error)
+}
+
+// unitFeeCollectors are the services billed per unit
+var unitFeeCollectors = []unitFeeCollector{
+	{Name: "secretsmanager", Service: "Secrets Manager", Unit: "secretsmanager:secret", ResourceType: models.SecretsManagerSecret, List: (*Client).listSecrets},
+	{Name: "kms", Service: "KMS", Unit: "kms:key", ResourceType: models.KMSKey, List: (*Client).listKMSKeys},
+	{Name: "route53", Service: "Route 53", Unit: "route53:hosted-zone", ResourceType: models.Route53HostedZone, Global: true, List: (*Client).listHostedZones},
+	{Name: "cloudwatch-alarms", Service: "CloudWatch", Unit: "cloudwatch:alarm", ResourceType: models.CloudWatchAlarm, List: (*Client).listCloudWatchAlarms},
+	{Name: "cloudwatch-metrics", Service: "CloudWatch", Unit: "cloudwatch:custom-metric", ResourceType: models.CloudWatchMetrics, List: (*Client).listCustomMetricNamespaces},
+}
+
+// collectUnitFees runs a collector and prices each item it returns
+func (c *Client) collectUnitFees(ctx context.Context, fee unitFeeCollector) ([]models.Resource, error) {
+	items, err := fee.List(c, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	region := c.Region
+	if fee.Global {
+		region = "global"
+	}
+
+	resources := make([]models.Resource, 0, len(items))
+	for _, item := range items {
+		unit := item.Unit
+		if unit == "" {
+			unit = fee.Unit
+		}
+		details := models.UnitFeeDetails{
+			Service:          fee.Service,
+			Unit:             unit,
+			Units:            item.Units,
+			UnitMonthlyPrice: pricing.UnitMonthlyFee(unit),
+			Attributes:       item.Attributes,
+		}
+		if details.Attributes == nil {
+			details.Attributes = make(map[string]string)
+		}
+		monthlyCost := details.Units * details.UnitMonthlyPrice
+
+		resources = append(resources, models.Resource{
+			ID:          item.ID,
+			Name:        item.Name,
+			Type:        fee.ResourceType,
+			Region:      region,
+			Status:      item.Status,
+			CreatedAt:   item.CreatedAt,
+			Details:     details,
+			DailyCost:   monthlyCost / 30,
+			MonthlyCost: monthlyCost,
+			Tags:        item.Tags,
+			Flags:       item.Flags,
+		})
+	}
+	return resources, nil
+}
@@ -0,0 +1,288 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/pricing"
+)
+
+// GetVPCEndpoints returns interface and Gateway Load Balancer endpoints, which are billed per
+// Availability Zone, and flags interface endpoints duplicated for a service within a VPC.
+// Gateway endpoints are free and not returned.
+func (c *Client) GetVPCEndpoints(ctx context.Context) ([]models.Resource, error) {
+	input := &ec2.DescribeVpcEndpointsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   stringPtr("vpc-endpoint-type"),
+				Values: []string{string(ec2types.VpcEndpointTypeInterface), string(ec2types.VpcEndpointTypeGatewayLoadBalancer)},
+			},
+		},
+	}
+
+	var endpoints []ec2types.VpcEndpoint
+	paginator := ec2.NewDescribeVpcEndpointsPaginator(c.EC2Client, input)
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing VPC endpoints: %v", err)
+			return nil, err
+		}
+		endpoints = append(endpoints, result.VpcEndpoints...)
+	}
+
+	var subnetIDs []string
+	for _, endpoint := range endpoints {
+		subnetIDs = append(subnetIDs, endpoint.SubnetIds...)
+	}
+	subnetZones, err := c.getSubnetZones(ctx, subnetIDs)
+	if err != nil {
+		log.Printf("Error describing subnets of VPC endpoints: %v", err)
+	}
+
+	// Interface endpoints for the same service in the same VPC, keyed by VPC and service
+	duplicates := make(map[string][]string)
+	for _, endpoint := range endpoints {
+		if endpoint.VpcEndpointType == ec2types.VpcEndpointTypeInterface {
+			key := derefString(endpoint.VpcId) + "/" + derefString(endpoint.ServiceName)
+			duplicates[key] = append(duplicates[key], *endpoint.VpcEndpointId)
+		}
+	}
+
+	resources := make([]models.Resource, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		id := *endpoint.VpcEndpointId
+		details := models.VPCEndpointDetails{
+			VPCID:             derefString(endpoint.VpcId),
+			ServiceName:       derefString(endpoint.ServiceName),
+			EndpointType:      string(endpoint.VpcEndpointType),
+			SubnetIDs:         endpoint.SubnetIds,
+			AvailabilityZones: zonesOf(endpoint.SubnetIds, subnetZones),
+			DuplicateOf:       make([]string, 0),
+		}
+		if endpoint.PrivateDnsEnabled != nil {
+			details.PrivateDNSEnabled = *endpoint.PrivateDnsEnabled
+		}
+
+		var flags []models.Flag
+		if endpoint.VpcEndpointType == ec2types.VpcEndpointTypeInterface {
+			for _, other := range duplicates[details.VPCID+"/"+details.ServiceName] {
+				if other != id {
+					details.DuplicateOf = append(details.DuplicateOf, other)
+				}
+			}
+		}
+		if len(details.DuplicateOf) > 0 {
+			flags = append(flags, models.Flag{
+				Code: "duplicate-endpoint",
+				Message: fmt.Sprintf("VPC %s has other interface endpoints for %s: %s",
+					details.VPCID, details.ServiceName, strings.Join(details.DuplicateOf, ", ")),
+			})
+		}
+
+		// An endpoint is billed in each zone it has a network interface in, even before
+		// subnet zones are resolved
+		monthlyCost := pricing.VPCEndpointMonthly(max(len(details.AvailabilityZones), len(endpoint.SubnetIds)))
+		tags := tagsFromEC2(endpoint.Tags)
+
+		resources = append(resources, models.Resource{
+			ID:          id,
+			Name:        nameFromTags(tags, id),
+			Type:        models.VPCEndpoint,
+			Region:      c.Region,
+			Status:      string(endpoint.State),
+			CreatedAt:   timeValue(endpoint.CreationTimestamp),
+			Details:     details,
+			DailyCost:   monthlyCost / 30,
+			MonthlyCost: monthlyCost,
+			Tags:        tags,
+			Flags:       flags,
+		})
+	}
+
+	return resources, nil
+}
+
+// GetTransitGatewayAttachments returns Transit Gateway attachments with the zones of VPC attachments
+func (c *Client) GetTransitGatewayAttachments(ctx context.Context) ([]models.Resource, error) {
+	input := &ec2.DescribeTransitGatewayAttachmentsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   stringPtr("state"),
+				Values: []string{string(ec2types.TransitGatewayAttachmentStateAvailable)},
+			},
+		},
+	}
+
+	var attachments []ec2types.TransitGatewayAttachment
+	paginator := ec2.NewDescribeTransitGatewayAttachmentsPaginator(c.EC2Client, input)
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing Transit Gateway attachments: %v", err)
+			return nil, err
+		}
+		attachments = append(attachments, result.TransitGatewayAttachments...)
+	}
+
+	vpcSubnets := make(map[string][]string)
+	var subnetIDs []string
+	vpcPaginator := ec2.NewDescribeTransitGatewayVpcAttachmentsPaginator(c.EC2Client, &ec2.DescribeTransitGatewayVpcAttachmentsInput{})
+	for vpcPaginator.HasMorePages() {
+		result, err := vpcPaginator.NextPage(ctx)
+		if err != nil {
+			log.Printf("Error describing Transit Gateway VPC attachments: %v", err)
+			break
+		}
+		for _, attachment := range result.TransitGatewayVpcAttachments {
+			vpcSubnets[derefString(attachment.TransitGatewayAttachmentId)] = attachment.SubnetIds
+			subnetIDs = append(subnetIDs, attachment.SubnetIds...)
+		}
+	}
+	subnetZones, err := c.getSubnetZones(ctx, subnetIDs)
+	if err != nil {
+		log.Printf("Error describing subnets of Transit Gateway attachments: %v", err)
+	}
+
+	resources := make([]models.Resource, 0, len(attachments))
+	for _, attachment := range attachments {
+		id := *attachment.TransitGatewayAttachmentId
+		subnets := vpcSubnets[id]
+		details := models.TransitGatewayAttachmentDetails{
+			TransitGatewayID:  derefString(attachment.TransitGatewayId),
+			ResourceType:      string(attachment.ResourceType),
+			ResourceID:        derefString(attachment.ResourceId),
+			ResourceOwnerID:   derefString(attachment.ResourceOwnerId),
+			SubnetIDs:         subnets,
+			AvailabilityZones: zonesOf(subnets, subnetZones),
+		}
+		if details.SubnetIDs == nil {
+			details.SubnetIDs = make([]string, 0)
+		}
+
+		monthlyCost := pricing.TransitGatewayAttachmentMonthly()
+		tags := tagsFromEC2(attachment.Tags)
+
+		resources = append(resources, models.Resource{
+			ID:          id,
+			Name:        nameFromTags(tags, id),
+			Type:        models.TransitGatewayAttachment,
+			Region:      c.Region,
+			Status:      string(attachment.State),
+			CreatedAt:   timeValue(attachment.CreationTime),
+			Details:     details,
+			DailyCost:   monthlyCost / 30,
+			MonthlyCost: monthlyCost,
+			Tags:        tags,
+		})
+	}
+
+	return resources, nil
+}
+
+// GetVPNConnections returns Site-to-Site VPN connections, flagging those with every tunnel down
+func (c *Client) GetVPNConnections(ctx context.Context) ([]models.Resource, error) {
+	result, err := c.EC2Client.DescribeVpnConnections(ctx, &ec2.DescribeVpnConnectionsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   stringPtr("state"),
+				Values: []string{string(ec2types.VpnStatePending), string(ec2types.VpnStateAvailable)},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error describing VPN connections: %v", err)
+		return nil, err
+	}
+
+	resources := make([]models.Resource, 0, len(result.VpnConnections))
+	for _, connection := range result.VpnConnections {
+		id := *connection.VpnConnectionId
+		details := models.VPNConnectionDetails{
+			VPNGatewayID:      derefString(connection.VpnGatewayId),
+			TransitGatewayID:  derefString(connection.TransitGatewayId),
+			CustomerGatewayID: derefString(connection.CustomerGatewayId),
+			Category:          derefString(connection.Category),
+			Tunnels:           len(connection.VgwTelemetry),
+		}
+		for _, tunnel := range connection.VgwTelemetry {
+			if tunnel.Status == ec2types.TelemetryStatusUp {
+				details.TunnelsUp++
+			}
+		}
+
+		var flags []models.Flag
+		if details.Tunnels > 0 && details.TunnelsUp == 0 {
+			flags = append(flags, models.Flag{
+				Code:    "tunnels-down",
+				Message: "All VPN tunnels are down but the connection is still billed hourly",
+			})
+		}
+
+		monthlyCost := pricing.VPNConnectionMonthly()
+		tags := tagsFromEC2(connection.Tags)
+
+		resources = append(resources, models.Resource{
+			ID:          id,
+			Name:        nameFromTags(tags, id),
+			Type:        models.VPNConnection,
+			Region:      c.Region,
+			Status:      string(connection.State),
+			Details:     details,
+			DailyCost:   monthlyCost / 30,
+			MonthlyCost: monthlyCost,
+			Tags:        tags,
+			Flags:       flags,
+		})
+	}
+
+	return resources, nil
+}
+
+// getSubnetZones returns the Availability Zone of each subnet
+func (c *Client) getSubnetZones(ctx context.Context, subnetIDs []string) (map[string]string, error) {
+	zones := make(map[string]string)
+	if len(subnetIDs) == 0 {
+		return zones, nil
+	}
+
+	paginator := ec2.NewDescribeSubnetsPaginator(c.EC2Client, &ec2.DescribeSubnetsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   stringPtr("subnet-id"),
+				Values: subnetIDs,
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			return zones, err
+		}
+		for _, subnet := range result.Subnets {
+			zones[derefString(subnet.SubnetId)] = derefString(subnet.AvailabilityZone)
+		}
+	}
+	return zones, nil
+}
+
+// zonesOf returns the sorted, distinct Availability Zones of the given subnets
+func zonesOf(subnetIDs []string, subnetZones map[string]string) []string {
+	seen := make(map[string]bool)
+	zones := make([]string, 0, len(subnetIDs))
+	for _, id := range subnetIDs {
+		if zone, ok := subnetZones[id]; ok && !seen[zone] {
+			seen[zone] = true
+			zones = append(zones, zone)
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
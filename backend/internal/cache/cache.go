@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Policy controls how long a cached value is served and how long fetching it may take
+type Policy struct {
+	// TTL is how long a value is served without refreshing it
+	TTL time.Duration
+	// StaleTTL is how long after TTL a stale value is still served while it is refreshed in the background
+	StaleTTL time.Duration
+	// Timeout limits each fetch
+	Timeout time.Duration
+}
+
+// entry is a cached value
+type entry struct {
+	value     any
+	fetchedAt time.Time
+	expiresAt time.Time
+}
+
+// call is a fetch in flight, shared by every caller asking for the same key
+type call struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// Cache holds fetched values by key. Concurrent requests for the same key share one fetch.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]entry
+	calls   map[string]*call
+}
+
+// New creates an empty cache
+func New() *Cache {
+	return &Cache{
+		entries: make(map[string]entry),
+		calls:   make(map[string]*call),
+	}
+}
+
+// Get returns the value cached under key. A fresh value is returned as is; a stale value is
+// returned while a background fetch refreshes it; otherwise, or when fresh is set, the caller
+// waits for fetch. Fetches run detached from ctx so a cancelled request does not fail others
+// waiting on the same fetch.
+func Get[T any](ctx context.Context, c *Cache, key string, policy Policy, fresh bool,
+	fetch func(ctx context.Context) (T, error)) (T, error) {
+	fetchAny := func(ctx context.Context) (any, error) {
+		return fetch(ctx)
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok && !fresh {
+		age := time.Since(e.fetchedAt)
+		if age < policy.TTL {
+			c.mu.Unlock()
+			return e.value.(T), nil
+		}
+		if age < policy.TTL+policy.StaleTTL {
+			c.startLocked(key, policy, fetchAny)
+			c.mu.Unlock()
+			return e.value.(T), nil
+		}
+	}
+	cl := c.startLocked(key, policy, fetchAny)
+	c.mu.Unlock()
+
+	var zero T
+	select {
+	case <-cl.done:
+		if cl.err != nil {
+			return zero, cl.err
+		}
+		return cl.value.(T), nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// startLocked starts fetching key unless a fetch is already in flight and returns the call.
+// c.mu must be held.
+func (c *Cache) startLocked(key string, policy Policy, fetch func(ctx context.Context) (any, error)) *call {
+	if cl, ok := c.calls[key]; ok {
+		return cl
+	}
+
+	cl := &call{done: make(chan struct{})}
+	c.calls[key] = cl
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), policy.Timeout)
+		defer cancel()
+		cl.value, cl.err = fetch(ctx)
+
+		c.mu.Lock()
+		now := time.Now()
+		if cl.err == nil {
+			c.entries[key] = entry{value: cl.value, fetchedAt: now, expiresAt: now.Add(policy.TTL + policy.StaleTTL)}
+		}
+		// Drop values too old to be served so the cache does not grow with one-off keys
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		delete(c.calls, key)
+		c.mu.Unlock()
+
+		close(cl.done)
+	}()
+
+	return cl
+}
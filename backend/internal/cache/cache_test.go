@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var longPolicy = Policy{TTL: time.Hour, StaleTTL: time.Hour, Timeout: time.Second}
+
+// value returns a fetch of a fixed value
+func value(v string) func(context.Context) (string, error) {
+	return func(context.Context) (string, error) { return v, nil }
+}
+
+// get calls Get and fails the test on an error
+func get(t *testing.T, c *Cache, key string, policy Policy, fresh bool, fetch func(context.Context) (string, error)) string {
+	t.Helper()
+	v, err := Get(context.Background(), c, key, policy, fresh, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// inFlight waits for the fetch of key started in the background, if any
+func inFlight(c *Cache, key string) {
+	c.mu.Lock()
+	cl, ok := c.calls[key]
+	c.mu.Unlock()
+	if ok {
+		<-cl.done
+	}
+}
+
+func TestConcurrentCallersShareOneFetch(t *testing.T) {
+	c := New()
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	fetch := func(context.Context) (string, error) {
+		fetches.Add(1)
+		<-release
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = Get(context.Background(), c, "key", longPolicy, false, fetch)
+		}()
+	}
+	// Callers arriving after the fetch finished are served the cached value
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("fetches = %d, want 1", n)
+	}
+	for i, result := range results {
+		if result != "value" {
+			t.Errorf("caller %d got %q", i, result)
+		}
+	}
+}
+
+func TestStaleValueServedDuringOneRefresh(t *testing.T) {
+	c := New()
+	policy := Policy{TTL: time.Millisecond, StaleTTL: time.Hour, Timeout: time.Second}
+	get(t, c, "key", policy, false, value("old"))
+	time.Sleep(5 * time.Millisecond)
+
+	var fetches atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	refresh := func(context.Context) (string, error) {
+		if fetches.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return "new", nil
+	}
+	for i := 0; i < 5; i++ {
+		if v := get(t, c, "key", policy, false, refresh); v != "old" {
+			t.Errorf("call %d during refresh = %q, want the stale value", i, v)
+		}
+	}
+	<-started
+	close(release)
+	inFlight(c, "key")
+
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("background fetches = %d, want 1", n)
+	}
+	c.mu.Lock()
+	refreshed := c.entries["key"].value
+	c.mu.Unlock()
+	if refreshed != "new" {
+		t.Errorf("cached value after refresh = %v, want new", refreshed)
+	}
+}
+
+func TestFreshWaitsForNewValue(t *testing.T) {
+	c := New()
+	get(t, c, "key", longPolicy, false, value("old"))
+
+	slow := func(context.Context) (string, error) {
+		time.Sleep(10 * time.Millisecond)
+		return "new", nil
+	}
+	if v := get(t, c, "key", longPolicy, true, slow); v != "new" {
+		t.Errorf("fresh value = %q, want new", v)
+	}
+	if v := get(t, c, "key", longPolicy, false, value("other")); v != "new" {
+		t.Errorf("cached value after fresh fetch = %q, want new", v)
+	}
+}
+
+func TestFetchErrorKeepsCachedValue(t *testing.T) {
+	c := New()
+	get(t, c, "key", longPolicy, false, value("old"))
+
+	failure := errors.New("throttled")
+	_, err := Get(context.Background(), c, "key", longPolicy, true, func(context.Context) (string, error) {
+		return "", failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("fresh fetch error = %v, want %v", err, failure)
+	}
+	if v := get(t, c, "key", longPolicy, false, value("other")); v != "old" {
+		t.Errorf("cached value after failed fetch = %q, want old", v)
+	}
+}
+
+func TestExpiredKeysEvicted(t *testing.T) {
+	c := New()
+	get(t, c, "one-off", Policy{TTL: time.Millisecond, StaleTTL: time.Millisecond, Timeout: time.Second}, false, value("old"))
+	time.Sleep(5 * time.Millisecond)
+
+	// Eviction runs when any fetch completes
+	get(t, c, "other", longPolicy, false, value("value"))
+
+	c.mu.Lock()
+	_, kept := c.entries["one-off"]
+	_, stored := c.entries["other"]
+	c.mu.Unlock()
+	if kept || !stored {
+		t.Errorf("after eviction one-off kept = %v, other stored = %v", kept, stored)
+	}
+}
@@ -0,0 +1,90 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// Collector gathers the resources of one service for an account and region
+type Collector interface {
+	// Name uniquely identifies the collector, e.g. "s3" or "ecr"
+	Name() string
+	// ResourceType is the primary type of resource the collector returns
+	ResourceType() models.ResourceType
+	// Collect returns the resources found in the given account and region
+	Collect(ctx context.Context, account, region string) ([]models.Resource, error)
+}
+
+// CollectFunc collects resources for an account and region
+type CollectFunc func(ctx context.Context, account, region string) ([]models.Resource, error)
+
+// funcCollector adapts a CollectFunc to the Collector interface
+type funcCollector struct {
+	name         string
+	resourceType models.ResourceType
+	collect      CollectFunc
+}
+
+// New returns a collector that calls collect
+func New(name string, resourceType models.ResourceType, collect CollectFunc) Collector {
+	return &funcCollector{name: name, resourceType: resourceType, collect: collect}
+}
+
+func (c *funcCollector) Name() string                      { return c.name }
+func (c *funcCollector) ResourceType() models.ResourceType { return c.resourceType }
+
+func (c *funcCollector) Collect(ctx context.Context, account, region string) ([]models.Resource, error) {
+	return c.collect(ctx, account, region)
+}
+
+// Registry holds collectors in registration order
+type Registry struct {
+	mu         sync.RWMutex
+	collectors []Collector
+	byName     map[string]Collector
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{byName: make(map[string]Collector)}
+}
+
+// Register adds collectors to the registry. It fails if a name is already registered.
+func (r *Registry) Register(collectors ...Collector) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range collectors {
+		if _, ok := r.byName[c.Name()]; ok {
+			return fmt.Errorf("collector %q is already registered", c.Name())
+		}
+		r.byName[c.Name()] = c
+		r.collectors = append(r.collectors, c)
+	}
+	return nil
+}
+
+// MustRegister adds collectors to the registry and panics if a name is already registered
+func (r *Registry) MustRegister(collectors ...Collector) {
+	if err := r.Register(collectors...); err != nil {
+		panic(err)
+	}
+}
+
+// Get returns the collector registered under name
+func (r *Registry) Get(name string) (Collector, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.byName[name]
+	return c, ok
+}
+
+// Collectors returns all registered collectors in registration order
+func (r *Registry) Collectors() []Collector {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Collector(nil), r.collectors...)
+}
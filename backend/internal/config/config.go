@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 )
 
 // Config holds the application configuration
@@ -10,6 +12,14 @@ type Config struct {
 	AWSRegion   string
 	CorsAllowed string
 	RefreshRate int // minutes
+	// SavedQueriesFile persists saved resource queries; empty keeps them in memory only
+	SavedQueriesFile string
+	// CURPath is a local directory or s3://bucket/prefix holding Cost and Usage Report files;
+	// empty disables CUR ingestion
+	CURPath string
+	// UnusedSecretDays is the number of days without access after which a Secrets Manager secret
+	// is flagged as unused
+	UnusedSecretDays int
 }
 
 // Load loads configuration from environment variables
@@ -32,10 +42,22 @@ func Load() (*Config, error) {
 	// Default refresh rate is 60 minutes (1 hour)
 	refreshRate := 60
 
+	unusedSecretDays := 90
+	if raw := os.Getenv("UNUSED_SECRET_DAYS"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil || days <= 0 {
+			return nil, fmt.Errorf("UNUSED_SECRET_DAYS must be a positive integer")
+		}
+		unusedSecretDays = days
+	}
+
 	return &Config{
-		Port:        port,
-		AWSRegion:   region,
-		CorsAllowed: cors,
-		RefreshRate: refreshRate,
+		Port:             port,
+		AWSRegion:        region,
+		CorsAllowed:      cors,
+		RefreshRate:      refreshRate,
+		SavedQueriesFile: os.Getenv("SAVED_QUERIES_FILE"),
+		CURPath:          os.Getenv("CUR_PATH"),
+		UnusedSecretDays: unusedSecretDays,
 	}, nil
 }
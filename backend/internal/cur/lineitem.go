@@ -0,0 +1,131 @@
+// Package cur ingests AWS Cost and Usage Report files, both CUR 2.0 data exports and legacy CUR,
+// as CSV, gzip-compressed CSV or Parquet, and aggregates their line items into hourly cost per
+// resource ID.
+package cur
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/tabular"
+)
+
+// LineItem is the part of a CUR line item used for resource costs
+type LineItem struct {
+	ResourceID    string
+	AccountID     string
+	ProductCode   string
+	LineItemType  string
+	Region        string
+	UsageStart    time.Time
+	UsageEnd      time.Time
+	UnblendedCost float64
+	Currency      string
+}
+
+// Column names after normalization. Legacy CUR CSV headers such as lineItem/UsageStartDate and
+// CUR 2.0 and legacy Parquet columns such as line_item_usage_start_date normalize the same way.
+const (
+	colResourceID    = "line_item_resource_id"
+	colAccountID     = "line_item_usage_account_id"
+	colProductCode   = "line_item_product_code"
+	colLineItemType  = "line_item_line_item_type"
+	colUsageStart    = "line_item_usage_start_date"
+	colUsageEnd      = "line_item_usage_end_date"
+	colUnblendedCost = "line_item_unblended_cost"
+	colCurrency      = "line_item_currency_code"
+	colRegionCode    = "product_region_code"
+	colRegion        = "product_region"
+)
+
+// requiredColumns must be present in every CUR file
+var requiredColumns = []string{colUsageStart, colUsageEnd, colUnblendedCost}
+
+// normalizeColumn converts a CUR column name to snake case, so lineItem/ResourceId becomes
+// line_item_resource_id
+func normalizeColumn(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '/' || r == ' ' || r == '-':
+			b.WriteByte('_')
+		case unicode.IsUpper(r):
+			if i > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Read reads the line items of a CUR file, calling fn for each one
+func Read(reader tabular.Reader, fn func(LineItem) error) error {
+	columns := make(map[string]string)
+	for _, column := range reader.Columns() {
+		columns[normalizeColumn(column)] = column
+	}
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			return fmt.Errorf("not a Cost and Usage Report: missing column %s", name)
+		}
+	}
+	get := func(row tabular.Row, name string) string {
+		return strings.TrimSpace(row[columns[name]])
+	}
+
+	for line := 2; ; line++ {
+		row, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		item := LineItem{
+			ResourceID:   get(row, colResourceID),
+			AccountID:    get(row, colAccountID),
+			ProductCode:  get(row, colProductCode),
+			LineItemType: get(row, colLineItemType),
+			Region:       get(row, colRegionCode),
+			Currency:     get(row, colCurrency),
+		}
+		if item.Region == "" {
+			item.Region = get(row, colRegion)
+		}
+		if item.UsageStart, err = parseTime(get(row, colUsageStart)); err != nil {
+			return fmt.Errorf("row %d: usage start date: %w", line, err)
+		}
+		if item.UsageEnd, err = parseTime(get(row, colUsageEnd)); err != nil {
+			return fmt.Errorf("row %d: usage end date: %w", line, err)
+		}
+		if cost := get(row, colUnblendedCost); cost != "" {
+			if item.UnblendedCost, err = strconv.ParseFloat(cost, 64); err != nil {
+				return fmt.Errorf("row %d: unblended cost: %w", line, err)
+			}
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+}
+
+// timeLayouts are the timestamp formats found in CUR files
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05Z0700", "2006-01-02 15:04:05", "2006-01-02T15:04Z", "2006-01-02"}
+
+// parseTime parses a CUR timestamp as UTC
+func parseTime(value string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+}
@@ -0,0 +1,228 @@
+package cur
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/tabular"
+)
+
+// File is a report file offered by a source
+type File struct {
+	Key      string
+	Size     int64
+	Modified time.Time
+}
+
+// Source lists and opens the report files of a CUR delivery
+type Source interface {
+	String() string
+	List(ctx context.Context) ([]File, error)
+	Open(ctx context.Context, file File) (tabular.Reader, error)
+}
+
+// NewSource returns the source for location: s3://bucket/prefix for a report delivered to S3,
+// or a local directory holding downloaded report files
+func NewSource(location string, client *s3.Client) (Source, error) {
+	if rest, ok := strings.CutPrefix(location, "s3://"); ok {
+		bucket, prefix, _ := strings.Cut(rest, "/")
+		if bucket == "" {
+			return nil, fmt.Errorf("invalid S3 location %q", location)
+		}
+		return &S3Source{Client: client, Bucket: bucket, Prefix: prefix}, nil
+	}
+
+	info, err := os.Stat(location)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", location)
+	}
+	return DirSource(location), nil
+}
+
+// legacyPeriod matches the billing period folder of a legacy CUR, such as 20260901-20261001
+var legacyPeriod = regexp.MustCompile(`^\d{8}-\d{8}$`)
+
+// latestVersions keeps, for each billing period, only the files of its most recently delivered
+// version. A legacy CUR writes every assembly of a period to <period>/<assemblyId>/ next to the
+// earlier ones, and a CUR 2.0 export that does not overwrite writes every run to a folder below
+// BILLING_PERIOD=<month>/, so each is a complete copy of the month. Files outside a billing
+// period folder are all kept.
+func latestVersions(files []File) []File {
+	type version struct {
+		name     string
+		modified time.Time
+	}
+	periodOf := func(key string) (period, name string, ok bool) {
+		segments := strings.Split(key, "/")
+		for i, segment := range segments[:len(segments)-1] {
+			if strings.HasPrefix(segment, "BILLING_PERIOD=") || legacyPeriod.MatchString(segment) {
+				period = strings.Join(segments[:i+1], "/")
+				if i+1 < len(segments)-1 {
+					name = segments[i+1]
+				}
+				return period, name, true
+			}
+		}
+		return "", "", false
+	}
+
+	latest := make(map[string]version)
+	for _, file := range files {
+		period, name, ok := periodOf(file.Key)
+		if !ok {
+			continue
+		}
+		// The version holding the most recently modified file is the latest delivery
+		current, seen := latest[period]
+		if !seen || file.Modified.After(current.modified) ||
+			file.Modified.Equal(current.modified) && name > current.name {
+			latest[period] = version{name: name, modified: file.Modified}
+		}
+	}
+
+	kept := files[:0:0]
+	for _, file := range files {
+		if period, name, ok := periodOf(file.Key); ok && latest[period].name != name {
+			continue
+		}
+		kept = append(kept, file)
+	}
+	return kept
+}
+
+// DirSource reads report files below a local directory
+type DirSource string
+
+func (d DirSource) String() string {
+	return string(d)
+}
+
+// List returns the CSV, CSV.gz and Parquet files below the directory
+func (d DirSource) List(ctx context.Context) ([]File, error) {
+	var files []File
+	err := filepath.WalkDir(string(d), func(p string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		if _, err := tabular.FormatOf(p); err != nil {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		key, err := filepath.Rel(string(d), p)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Key: filepath.ToSlash(key), Size: info.Size(), Modified: info.ModTime()})
+		return nil
+	})
+	return files, err
+}
+
+// Open opens a report file
+func (d DirSource) Open(ctx context.Context, file File) (tabular.Reader, error) {
+	return tabular.Open(filepath.Join(string(d), filepath.FromSlash(file.Key)))
+}
+
+// S3Source reads report files below a prefix of an S3 bucket. Point AWS_ENDPOINT_URL_S3 at an
+// S3-compatible store such as MinIO or LocalStack to read from a stand-in.
+type S3Source struct {
+	Client *s3.Client
+	Bucket string
+	Prefix string
+}
+
+func (s *S3Source) String() string {
+	return "s3://" + path.Join(s.Bucket, s.Prefix)
+}
+
+// List returns the CSV, CSV.gz and Parquet objects below the prefix
+func (s *S3Source) List(ctx context.Context) ([]File, error) {
+	var files []File
+	paginator := s3.NewListObjectsV2Paginator(s.Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.Bucket),
+		Prefix: aws.String(s.Prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range page.Contents {
+			key := aws.ToString(object.Key)
+			if _, err := tabular.FormatOf(key); err != nil {
+				continue
+			}
+			files = append(files, File{Key: key, Size: aws.ToInt64(object.Size), Modified: aws.ToTime(object.LastModified)})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Key < files[j].Key })
+	return files, nil
+}
+
+// Open downloads a report object to a temporary file, removed when the reader is closed.
+// Parquet needs random access, so objects are not read as a stream.
+func (s *S3Source) Open(ctx context.Context, file File) (tabular.Reader, error) {
+	object, err := s.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(file.Key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer object.Body.Close()
+
+	format, err := tabular.FormatOf(file.Key)
+	if err != nil {
+		return nil, err
+	}
+	temp, err := os.CreateTemp("", "cur-*."+string(format))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(temp, object.Body); err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		return nil, err
+	}
+	if err := temp.Close(); err != nil {
+		os.Remove(temp.Name())
+		return nil, err
+	}
+
+	reader, err := tabular.Open(temp.Name())
+	if err != nil {
+		os.Remove(temp.Name())
+		return nil, err
+	}
+	return tempReader{Reader: reader, path: temp.Name()}, nil
+}
+
+// tempReader removes its temporary file when closed
+type tempReader struct {
+	tabular.Reader
+	path string
+}
+
+func (t tempReader) Close() error {
+	err := t.Reader.Close()
+	os.Remove(t.path)
+	return err
+}
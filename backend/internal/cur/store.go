@@ -0,0 +1,276 @@
+package cur
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// maxSpreadHours bounds how many hours a single line item's cost is spread across
+const maxSpreadHours = 24 * 366
+
+// Store holds the hourly cost per resource ID of every file ingested from a source
+type Store struct {
+	source   Source
+	syncMu   sync.Mutex
+	mu       sync.RWMutex
+	files    map[string]*file
+	lastSync time.Time
+}
+
+// file is an ingested report file
+type file struct {
+	info models.CURFile
+	// hourly maps resource IDs to the cost in each hour, keyed by the hour's Unix time
+	hourly map[string]map[int64]float64
+	// short maps the last segment of ARN resource IDs to the full ID, or "" when ambiguous
+	short map[string]string
+	// usageEnd is the end of the latest hourly or daily line item
+	usageEnd time.Time
+}
+
+// NewStore creates a store for the files of source. Nothing is read until Sync is called.
+func NewStore(source Source) *Store {
+	return &Store{source: source, files: make(map[string]*file)}
+}
+
+// Source returns the location files are read from
+func (s *Store) Source() string {
+	return s.source.String()
+}
+
+// Sync ingests the files that are new or changed since the last sync and forgets files that are
+// no longer offered by the source or were replaced by a later delivery of their billing period.
+// A file that fails to ingest is recorded with its error.
+func (s *Store) Sync(ctx context.Context) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	listed, err := s.source.List(ctx)
+	if err != nil {
+		log.Printf("Error listing CUR files in %s: %v", s.source, err)
+		return err
+	}
+	listed = latestVersions(listed)
+
+	s.mu.RLock()
+	current := s.files
+	s.mu.RUnlock()
+
+	files := make(map[string]*file, len(listed))
+	var errs []error
+	for _, listedFile := range listed {
+		if existing, ok := current[listedFile.Key]; ok && existing.info.Error == "" &&
+			existing.info.Size == listedFile.Size && existing.info.Modified.Equal(listedFile.Modified) {
+			files[listedFile.Key] = existing
+			continue
+		}
+
+		ingested, err := s.ingest(ctx, listedFile)
+		if err != nil {
+			log.Printf("Error ingesting CUR file %s: %v", listedFile.Key, err)
+			errs = append(errs, fmt.Errorf("%s: %w", listedFile.Key, err))
+		}
+		files[listedFile.Key] = ingested
+	}
+
+	s.mu.Lock()
+	s.files = files
+	s.lastSync = time.Now()
+	s.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// ingest reads the line items of one file. On error the returned file records the error and
+// holds no costs.
+func (s *Store) ingest(ctx context.Context, source File) (*file, error) {
+	f := &file{
+		info: models.CURFile{
+			Key:        source.Key,
+			Size:       source.Size,
+			Modified:   source.Modified,
+			IngestedAt: time.Now(),
+		},
+		hourly: make(map[string]map[int64]float64),
+	}
+
+	reader, err := s.source.Open(ctx, source)
+	if err != nil {
+		f.info.Error = err.Error()
+		return f, err
+	}
+	defer reader.Close()
+
+	err = Read(reader, func(item LineItem) error {
+		f.add(item)
+		return nil
+	})
+	if err != nil {
+		*f = file{info: models.CURFile{
+			Key:        source.Key,
+			Size:       source.Size,
+			Modified:   source.Modified,
+			IngestedAt: f.info.IngestedAt,
+			Error:      err.Error(),
+		}}
+		return f, err
+	}
+
+	f.info.ResourceIDs = len(f.hourly)
+	f.short = make(map[string]string, len(f.hourly))
+	for id := range f.hourly {
+		short := shortID(id)
+		if existing, ok := f.short[short]; ok && existing != id {
+			f.short[short] = ""
+			continue
+		}
+		f.short[short] = id
+	}
+	return f, nil
+}
+
+// add records a line item, spreading its cost evenly over the hours of its usage period
+func (f *file) add(item LineItem) {
+	f.info.LineItems++
+	f.info.UnblendedCost += item.UnblendedCost
+	if f.info.Currency == "" {
+		f.info.Currency = item.Currency
+	}
+	if f.info.PeriodStart.IsZero() || item.UsageStart.Before(f.info.PeriodStart) {
+		f.info.PeriodStart = item.UsageStart
+	}
+	if item.UsageEnd.After(f.info.PeriodEnd) {
+		f.info.PeriodEnd = item.UsageEnd
+	}
+	// Monthly fees span the whole billing period, so they do not tell how far usage has been reported
+	if item.UsageEnd.Sub(item.UsageStart) <= 24*time.Hour && item.UsageEnd.After(f.usageEnd) {
+		f.usageEnd = item.UsageEnd
+	}
+
+	if item.ResourceID == "" || item.UnblendedCost == 0 {
+		return
+	}
+	hours, ok := f.hourly[item.ResourceID]
+	if !ok {
+		hours = make(map[int64]float64)
+		f.hourly[item.ResourceID] = hours
+	}
+
+	start := item.UsageStart.Truncate(time.Hour)
+	count := int(item.UsageEnd.Sub(start) / time.Hour)
+	if item.UsageEnd.Sub(start)%time.Hour != 0 {
+		count++
+	}
+	if count < 1 || count > maxSpreadHours {
+		count = 1
+	}
+	share := item.UnblendedCost / float64(count)
+	for i := 0; i < count; i++ {
+		hours[start.Add(time.Duration(i)*time.Hour).Unix()] += share
+	}
+}
+
+// costs returns the hourly costs of a resource, matching ARNs to bare IDs and the reverse
+func (f *file) costs(id string) (map[int64]float64, bool) {
+	if hours, ok := f.hourly[id]; ok {
+		return hours, true
+	}
+	if full := f.short[shortID(id)]; full != "" {
+		return f.hourly[full], true
+	}
+	return nil, false
+}
+
+// shortID returns the last segment of an ARN, such as the instance ID of an EC2 instance ARN
+func shortID(id string) string {
+	if !strings.HasPrefix(id, "arn:") {
+		return id
+	}
+	return id[strings.LastIndexAny(id, ":/")+1:]
+}
+
+// Files returns the ingested files sorted by key
+func (s *Store) Files() []models.CURFile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	files := make([]models.CURFile, 0, len(s.files))
+	for _, f := range s.files {
+		files = append(files, f.info)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Key < files[j].Key })
+	return files
+}
+
+// LastSync returns when the source was last synced
+func (s *Store) LastSync() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastSync
+}
+
+// Currency returns the billing currency of the ingested files
+func (s *Store) Currency() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, f := range s.files {
+		if f.info.Currency != "" {
+			return f.info.Currency
+		}
+	}
+	return "USD"
+}
+
+// LatestDay returns the start of the last complete UTC day of usage in the ingested files
+func (s *Store) LatestDay() (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var end time.Time
+	for _, f := range s.files {
+		if f.usageEnd.After(end) {
+			end = f.usageEnd
+		}
+	}
+	if end.IsZero() {
+		return time.Time{}, false
+	}
+	return end.Truncate(24*time.Hour).AddDate(0, 0, -1), true
+}
+
+// Cost returns the cost of a resource in [start, end), summed into buckets of granularity
+// (an hour or a day). found is false when no ingested file has line items for the resource.
+func (s *Store) Cost(id string, start, end time.Time, granularity time.Duration) (points []models.CostPoint, total float64, found bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	buckets := make(map[int64]float64)
+	from, to := start.Unix(), end.Unix()
+	for _, f := range s.files {
+		hours, ok := f.costs(id)
+		if !ok {
+			continue
+		}
+		found = true
+		for hour, cost := range hours {
+			if hour < from || hour >= to {
+				continue
+			}
+			bucket := time.Unix(hour, 0).UTC().Truncate(granularity).Unix()
+			buckets[bucket] += cost
+			total += cost
+		}
+	}
+
+	points = make([]models.CostPoint, 0, len(buckets))
+	for bucket, cost := range buckets {
+		points = append(points, models.CostPoint{Start: time.Unix(bucket, 0).UTC(), Cost: cost})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Start.Before(points[j].Start) })
+	return points, total, found
+}
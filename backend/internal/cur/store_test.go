@@ -0,0 +1,87 @@
+package cur
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// writeReport writes a legacy CUR CSV charging the resource cost over September 1 and sets its
+// modification time
+func writeReport(t *testing.T, dir, key string, cost string, modified time.Time) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	report := "lineItem/ResourceId,lineItem/UsageStartDate,lineItem/UsageEndDate,lineItem/UnblendedCost\n" +
+		"i-1,2026-09-01T00:00:00Z,2026-09-02T00:00:00Z," + cost + "\n"
+	if err := os.WriteFile(path, []byte(report), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncReadsLatestVersionOfEachMonth(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		first, second string
+	}{
+		{"legacy assemblies", "cur/report/20260901-20261001/assembly-b/report-1.csv", "cur/report/20260901-20261001/assembly-a/report-1.csv"},
+		{"CUR 2.0 runs", "export/data/BILLING_PERIOD=2026-09/run-1/export-00001.csv", "export/data/BILLING_PERIOD=2026-09/run-2/export-00001.csv"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			delivered := time.Date(2026, 9, 2, 6, 0, 0, 0, time.UTC)
+			writeReport(t, dir, test.first, "24", delivered)
+			writeReport(t, dir, test.second, "48", delivered.Add(8*time.Hour))
+
+			store := NewStore(DirSource(dir))
+			if err := store.Sync(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			day := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+			if _, total, _ := store.Cost("i-1", day, day.AddDate(0, 0, 1), 24*time.Hour); total != 48 {
+				t.Errorf("cost = %v, want 48 from the later version only", total)
+			}
+			var keys []string
+			for _, file := range store.Files() {
+				keys = append(keys, file.Key)
+			}
+			if !slices.Equal(keys, []string{test.second}) {
+				t.Errorf("files = %v, want %v", keys, test.second)
+			}
+		})
+	}
+}
+
+func TestLatestVersionsKeepsOtherFiles(t *testing.T) {
+	september := time.Date(2026, 9, 2, 0, 0, 0, 0, time.UTC)
+	files := []File{
+		{Key: "report/20260901-20261001/old/report-1.csv.gz", Modified: september},
+		{Key: "report/20260901-20261001/new/report-1.csv.gz", Modified: september.Add(time.Hour)},
+		{Key: "report/20260901-20261001/new/report-2.csv.gz", Modified: september.Add(-time.Hour)},
+		{Key: "report/20261001-20261101/report-1.csv.gz", Modified: september},
+		{Key: "export/data/BILLING_PERIOD=2026-10/export-00001.parquet", Modified: september},
+		{Key: "downloads/september.csv", Modified: september},
+	}
+	var keys []string
+	for _, file := range latestVersions(files) {
+		keys = append(keys, file.Key)
+	}
+	want := []string{
+		"report/20260901-20261001/new/report-1.csv.gz",
+		"report/20260901-20261001/new/report-2.csv.gz",
+		"report/20261001-20261101/report-1.csv.gz",
+		"export/data/BILLING_PERIOD=2026-10/export-00001.parquet",
+		"downloads/september.csv",
+	}
+	if !slices.Equal(keys, want) {
+		t.Errorf("kept %v, want %v", keys, want)
+	}
+}
@@ -0,0 +1,102 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event types
+const (
+	RefreshStarted   = "refresh.started"
+	RefreshProgress  = "refresh.progress"
+	RefreshCompleted = "refresh.completed"
+	Alert            = "alert"
+	ResourcesChanged = "resources.changed"
+	// Resync tells a resuming subscriber that events it missed are no longer held, so it should
+	// fetch the current state again. It is sent only to that subscriber.
+	Resync = "resync"
+)
+
+const (
+	// subscriberBuffer is the number of events queued for a subscriber before new events are dropped
+	subscriberBuffer = 64
+
+	// replaySize is the number of recent events kept for clients resuming with a last event ID
+	replaySize = 100
+)
+
+// Event is a message pushed to subscribers
+type Event struct {
+	ID   int64     `json:"id"`
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+	Data any       `json:"data"`
+}
+
+// Broker fans events out to subscribers. Slow subscribers miss events rather than block publishers.
+type Broker struct {
+	mu          sync.Mutex
+	nextID      int64
+	subscribers map[chan Event]struct{}
+	recent      []Event
+}
+
+// NewBroker creates a broker without subscribers
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[chan Event]struct{})}
+}
+
+// Publish sends an event to every subscriber
+func (b *Broker) Publish(eventType string, data any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	event := Event{ID: b.nextID, Type: eventType, Time: time.Now(), Data: data}
+
+	b.recent = append(b.recent, event)
+	if len(b.recent) > replaySize {
+		b.recent = b.recent[len(b.recent)-replaySize:]
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel receiving events published after lastID, starting with any such
+// events still held for replay, and a function that ends the subscription. When some of those
+// events are no longer held, or lastID is from before a restart, a single Resync event carrying
+// the latest event ID is sent instead.
+func (b *Broker) Subscribe(lastID int64) (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan Event, subscriberBuffer+replaySize)
+	if lastID > 0 {
+		missed := lastID > b.nextID || (len(b.recent) > 0 && b.recent[0].ID > lastID+1)
+		if missed {
+			ch <- Event{ID: b.nextID, Type: Resync, Time: time.Now()}
+		} else {
+			for _, event := range b.recent {
+				if event.ID > lastID {
+					ch <- event
+				}
+			}
+		}
+	}
+	b.subscribers[ch] = struct{}{}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe
+}
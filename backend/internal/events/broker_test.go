@@ -0,0 +1,105 @@
+package events
+
+import "testing"
+
+// drain returns the events queued on ch without waiting for more
+func drain(ch <-chan Event) []Event {
+	var received []Event
+	for {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return received
+			}
+			received = append(received, event)
+		default:
+			return received
+		}
+	}
+}
+
+// publish publishes n alert events
+func publish(b *Broker, n int) {
+	for i := 0; i < n; i++ {
+		b.Publish(Alert, i)
+	}
+}
+
+func TestSubscribeReplaysMissedEvents(t *testing.T) {
+	b := NewBroker()
+	publish(b, 5)
+
+	ch, unsubscribe := b.Subscribe(3)
+	defer unsubscribe()
+	received := drain(ch)
+	if len(received) != 2 || received[0].ID != 4 || received[1].ID != 5 {
+		t.Errorf("replayed %+v, want events 4 and 5", received)
+	}
+
+	// Without a last event ID nothing is replayed
+	fresh, unsubscribeFresh := b.Subscribe(0)
+	defer unsubscribeFresh()
+	if received := drain(fresh); len(received) != 0 {
+		t.Errorf("new subscriber replayed %+v", received)
+	}
+}
+
+func TestSubscribeResyncsAfterReplayWindow(t *testing.T) {
+	b := NewBroker()
+	publish(b, replaySize+10)
+
+	for _, test := range []struct {
+		name   string
+		lastID int64
+		resync bool
+	}{
+		{"last held event", replaySize + 10, false},
+		{"just before the window", 10, false},
+		{"older than the window", 9, true},
+		{"from before a restart", replaySize + 50, true},
+	} {
+		ch, unsubscribe := b.Subscribe(test.lastID)
+		received := drain(ch)
+		unsubscribe()
+
+		resynced := len(received) == 1 && received[0].Type == Resync && received[0].ID == replaySize+10
+		if resynced != test.resync {
+			t.Errorf("%s: received %d events starting %+v, want resync %v", test.name, len(received), received[:min(len(received), 1)], test.resync)
+		}
+	}
+}
+
+func TestPublishDropsEventsForFullSubscriber(t *testing.T) {
+	b := NewBroker()
+	ch, unsubscribe := b.Subscribe(0)
+	defer unsubscribe()
+
+	// Publishing never blocks on a subscriber that does not read
+	publish(b, subscriberBuffer+replaySize+10)
+
+	received := drain(ch)
+	if len(received) != subscriberBuffer+replaySize {
+		t.Fatalf("received %d events, want %d", len(received), subscriberBuffer+replaySize)
+	}
+	if last := received[len(received)-1].ID; last != subscriberBuffer+replaySize {
+		t.Errorf("last received event = %d, want the newest events dropped", last)
+	}
+
+	b.Publish(Alert, "after")
+	if received := drain(ch); len(received) != 1 {
+		t.Errorf("after draining received %d events, want 1", len(received))
+	}
+}
+
+func TestUnsubscribeClosesChannel(t *testing.T) {
+	b := NewBroker()
+	ch, unsubscribe := b.Subscribe(0)
+	unsubscribe()
+	unsubscribe()
+
+	if _, ok := <-ch; ok {
+		t.Error("channel open after unsubscribe")
+	}
+	// Publishing after unsubscribing does not send on the closed channel
+	b.Publish(Alert, nil)
+}
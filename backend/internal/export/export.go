@@ -0,0 +1,295 @@
+// Package export streams lists of records as CSV, XLSX or JSON Lines, flattening nested fields
+// into columns.
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Format is an export file format
+type Format string
+
+const (
+	CSV    Format = "csv"
+	XLSX   Format = "xlsx"
+	NDJSON Format = "ndjson"
+)
+
+// ParseFormat reads a format name, accepting "jsonl" as an alias of "ndjson"
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "", "csv":
+		return CSV, nil
+	case "xlsx":
+		return XLSX, nil
+	case "ndjson", "jsonl":
+		return NDJSON, nil
+	}
+	return "", fmt.Errorf("format must be csv, xlsx or ndjson")
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	switch f {
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case NDJSON:
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Extension returns the file extension of the format
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// rowWriter writes a header and then one row at a time
+type rowWriter interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []any) error
+	Close() error
+}
+
+// newRowWriter returns a writer for the format
+func newRowWriter(w io.Writer, format Format, sheet string) rowWriter {
+	switch format {
+	case XLSX:
+		return newXLSXWriter(w, sheet)
+	case NDJSON:
+		return newNDJSONWriter(w)
+	}
+	return newCSVWriter(w)
+}
+
+// Write streams records in the given format. Each record is flattened: nested objects become
+// dotted columns (details.sizeBytes), tags become tag:<key> columns and lists are joined with
+// semicolons, as are the codes of resource flags. With no columns, every column found in any record is written, tags last.
+func Write[T any](w io.Writer, format Format, sheet string, records []T, columns []string) error {
+	if len(columns) == 0 {
+		var err error
+		if columns, err = Columns(records); err != nil {
+			return err
+		}
+	}
+
+	out := newRowWriter(w, format, sheet)
+	if err := out.WriteHeader(columns); err != nil {
+		return err
+	}
+	values := make([]any, len(columns))
+	for _, record := range records {
+		fields, err := flatten(record)
+		if err != nil {
+			return err
+		}
+		for i, column := range columns {
+			values[i] = fields[column]
+		}
+		if err := out.WriteRow(values); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// Columns returns every column of records in first-seen order, with tag columns sorted at the end.
+// An empty list gets the columns of an empty record, so the file still has a header.
+func Columns[T any](records []T) ([]string, error) {
+	if len(records) == 0 {
+		records = make([]T, 1)
+	}
+
+	var columns, tags []string
+	seen := make(map[string]bool)
+	for _, record := range records {
+		keys, err := flattenKeys(record)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if strings.HasPrefix(key, "tag:") {
+				tags = append(tags, key)
+			} else {
+				columns = append(columns, key)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return append(columns, tags...), nil
+}
+
+// ParseColumns splits a comma-separated column list
+func ParseColumns(raw string) []string {
+	var columns []string
+	for _, column := range strings.Split(raw, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// flatten returns the flattened fields of a record
+func flatten(record any) (map[string]any, error) {
+	fields := make(map[string]any)
+	err := walk(record, func(key string, value any) { fields[key] = value })
+	return fields, err
+}
+
+// flattenKeys returns the flattened column names of a record in field order
+func flattenKeys(record any) ([]string, error) {
+	var keys []string
+	err := walk(record, func(key string, _ any) { keys = append(keys, key) })
+	return keys, err
+}
+
+// walk encodes a record as JSON and calls emit for each flattened field in order. Numbers are
+// passed as json.Number so they keep their exact text.
+func walk(record any, emit func(key string, value any)) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return walkValue(data, "", emit)
+}
+
+func walkValue(data json.RawMessage, prefix string, emit func(string, any)) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil
+	}
+
+	switch data[0] {
+	case '{':
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.Token()
+		for dec.More() {
+			token, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key := token.(string)
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return err
+			}
+			if prefix == "" && key == "tags" {
+				if err := walkTags(value, emit); err != nil {
+					return err
+				}
+				continue
+			}
+			if prefix == "" && key == "flags" {
+				if err := walkFlags(value, emit); err != nil {
+					return err
+				}
+				continue
+			}
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			if err := walkValue(value, key, emit); err != nil {
+				return err
+			}
+		}
+		return nil
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		parts := make([]string, 0, len(items))
+		for _, item := range items {
+			value, err := scalar(item)
+			if err != nil {
+				// Lists of objects are kept as JSON
+				emit(prefix, string(data))
+				return nil
+			}
+			parts = append(parts, fmt.Sprint(value))
+		}
+		emit(prefix, strings.Join(parts, ";"))
+		return nil
+	}
+
+	value, err := scalar(data)
+	if err != nil {
+		return err
+	}
+	// Nulls are left out so that a missing object does not add a column of its own
+	if value != nil {
+		emit(prefix, value)
+	}
+	return nil
+}
+
+// walkTags emits a tag:<key> column for each tag, whether tags are a [{key, value}] list or
+// a key/value object
+func walkTags(data json.RawMessage, emit func(string, any)) error {
+	var list []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(data, &list); err == nil {
+		for _, tag := range list {
+			emit("tag:"+tag.Key, tag.Value)
+		}
+		return nil
+	}
+	var object map[string]string
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		emit("tag:"+key, object[key])
+	}
+	return nil
+}
+
+// walkFlags emits the codes of resource flags as one semicolon-separated column
+func walkFlags(data json.RawMessage, emit func(string, any)) error {
+	var flags []struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(data, &flags); err != nil {
+		return walkValue(data, "flags", emit)
+	}
+	if flags == nil {
+		return nil
+	}
+	codes := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		codes = append(codes, flag.Code)
+	}
+	emit("flags", strings.Join(codes, ";"))
+	return nil
+}
+
+// scalar decodes a JSON string, number, boolean or null
+func scalar(data json.RawMessage) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := token.(json.Delim); ok {
+		return nil, fmt.Errorf("not a scalar")
+	}
+	return token, nil
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+func TestCSVEscapesFormulas(t *testing.T) {
+	records := []models.Resource{{
+		ID:          "=HYPERLINK(\"http://example.com\")",
+		Name:        "@SUM(A1)",
+		Region:      "-1+1",
+		MonthlyCost: -2.5,
+		Tags:        []models.Tag{{Key: "team", Value: "+data"}},
+	}}
+
+	var out bytes.Buffer
+	if err := Write(&out, CSV, "resources", records, []string{"id", "name", "region", "monthlyCost", "tag:team"}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if want := `"'=HYPERLINK(""http://example.com"")",'@SUM(A1),'-1+1,-2.5,'+data`; lines[1] != want {
+		t.Errorf("row = %s, want %s", lines[1], want)
+	}
+}
+
+func TestRecommendationColumns(t *testing.T) {
+	columns, err := Columns([]models.Recommendation{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if columns[len(columns)-2] != "monthlyCost" || columns[len(columns)-1] != "monthlySavings" {
+		t.Errorf("columns = %v, want monthlyCost and monthlySavings last", columns)
+	}
+}
@@ -0,0 +1,33 @@
+package export
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Params are query parameters read by Respond, to be skipped by list filters
+var Params = []string{"format", "columns", "dataset"}
+
+// Respond streams records as an attachment named after the dataset, in the ?format= given
+// (csv by default) and with the ?columns= selected
+func Respond[T any](c *gin.Context, dataset string, records []T) {
+	format, err := ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", dataset, time.Now().Format("20060102"), format.Extension())
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// Headers are already sent, so a failure part way through can only be logged
+	if err := Write(c.Writer, format, dataset, records, ParseColumns(c.Query("columns"))); err != nil {
+		log.Printf("Error exporting %s: %v", dataset, err)
+	}
+}
@@ -0,0 +1,229 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// flushEvery is the number of rows buffered before output is flushed to the client
+const flushEvery = 500
+
+// text renders a value for CSV
+func text(value any) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// csvCell renders a value for CSV, prefixing text that a spreadsheet would run as a formula
+// with a quote so names and tags cannot inject one
+func csvCell(value any) string {
+	cell := text(value)
+	if cell != "" && strings.ContainsRune("=+-@", rune(cell[0])) {
+		if _, isString := value.(string); isString {
+			return "'" + cell
+		}
+	}
+	return cell
+}
+
+// csvWriter writes comma-separated values
+type csvWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) WriteHeader(columns []string) error {
+	return c.w.Write(columns)
+}
+
+func (c *csvWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = csvCell(value)
+	}
+	if err := c.w.Write(record); err != nil {
+		return err
+	}
+	if c.rows++; c.rows%flushEvery == 0 {
+		c.w.Flush()
+	}
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ndjsonWriter writes one JSON object per line, keyed by column
+type ndjsonWriter struct {
+	w       *bufio.Writer
+	columns []string
+	rows    int
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	return &ndjsonWriter{w: bufio.NewWriter(w)}
+}
+
+func (n *ndjsonWriter) WriteHeader(columns []string) error {
+	n.columns = columns
+	return nil
+}
+
+func (n *ndjsonWriter) WriteRow(values []any) error {
+	// Write keys in column order rather than through a map, which would sort them
+	n.w.WriteByte('{')
+	for i, column := range n.columns {
+		if i > 0 {
+			n.w.WriteByte(',')
+		}
+		key, _ := json.Marshal(column)
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		n.w.Write(key)
+		n.w.WriteByte(':')
+		n.w.Write(value)
+	}
+	n.w.WriteString("}\n")
+	if n.rows++; n.rows%flushEvery == 0 {
+		return n.w.Flush()
+	}
+	return nil
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.w.Flush()
+}
+
+// xlsxWriter writes a single-sheet workbook. Strings are written inline rather than to a shared
+// string table so rows can be streamed straight into the zip entry.
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet string
+	w     *bufio.Writer
+}
+
+func newXLSXWriter(w io.Writer, sheet string) *xlsxWriter {
+	if sheet == "" {
+		sheet = "Export"
+	}
+	return &xlsxWriter{zip: zip.NewWriter(w), sheet: sheetName(sheet)}
+}
+
+// xlsxParts are the fixed parts of the workbook package
+var xlsxParts = []struct{ name, body string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`},
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs></styleSheet>`},
+}
+
+func (x *xlsxWriter) WriteHeader(columns []string) error {
+	for _, part := range xlsxParts {
+		if err := x.writePart(part.name, part.body); err != nil {
+			return err
+		}
+	}
+	workbook := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="` +
+		escape(x.sheet) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	if err := x.writePart("xl/workbook.xml", workbook); err != nil {
+		return err
+	}
+
+	// The worksheet stays open until Close so rows are written as they arrive
+	entry, err := x.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	x.w = bufio.NewWriter(entry)
+	x.w.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews><sheetData><row>`)
+	for _, column := range columns {
+		x.w.WriteString(`<c t="inlineStr" s="1"><is><t>` + escape(column) + `</t></is></c>`)
+	}
+	_, err = x.w.WriteString("</row>")
+	return err
+}
+
+func (x *xlsxWriter) WriteRow(values []any) error {
+	x.w.WriteString("<row>")
+	for _, value := range values {
+		switch value := value.(type) {
+		case nil:
+			x.w.WriteString("<c/>")
+		case json.Number:
+			x.w.WriteString("<c><v>" + value.String() + "</v></c>")
+		case bool:
+			v := "0"
+			if value {
+				v = "1"
+			}
+			x.w.WriteString(`<c t="b"><v>` + v + "</v></c>")
+		default:
+			x.w.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">` + escape(text(value)) + "</t></is></c>")
+		}
+	}
+	_, err := x.w.WriteString("</row>")
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	if x.w != nil {
+		x.w.WriteString("</sheetData></worksheet>")
+		if err := x.w.Flush(); err != nil {
+			return err
+		}
+	}
+	return x.zip.Close()
+}
+
+// writePart adds a complete file to the workbook package
+func (x *xlsxWriter) writePart(name, body string) error {
+	entry, err := x.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(entry, body)
+	return err
+}
+
+// escape escapes text for XML, replacing characters XML cannot hold
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// sheetName makes a valid worksheet name: at most 31 characters and none of []:*?/\
+func sheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, name)
+	if len(name) > 31 {
+		name = name[:31]
+	}
+	return name
+}
@@ -0,0 +1,172 @@
+package focus
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// awsProvider is the provider, publisher and invoice issuer of AWS charges
+const awsProvider = "AWS"
+
+// IsAWS reports whether a FOCUS ProviderName names AWS
+func IsAWS(provider string) bool {
+	return strings.EqualFold(provider, awsProvider) || strings.EqualFold(provider, "Amazon Web Services")
+}
+
+// serviceCategories maps keywords of AWS service names to FOCUS service categories. The first
+// match wins, so more specific keywords come first.
+var serviceCategories = []struct{ keyword, category string }{
+	{"sagemaker", "AI and Machine Learning"},
+	{"bedrock", "AI and Machine Learning"},
+	{"athena", "Analytics"},
+	{"glue", "Analytics"},
+	{"kinesis", "Analytics"},
+	{"opensearch", "Analytics"},
+	{"elasticsearch", "Analytics"},
+	{"redshift", "Analytics"},
+	{"relational database", "Databases"},
+	{"dynamodb", "Databases"},
+	{"elasticache", "Databases"},
+	{"documentdb", "Databases"},
+	{"aurora", "Databases"},
+	{"simple storage service", "Storage"},
+	{"s3", "Storage"},
+	{"elastic file system", "Storage"},
+	{"efs", "Storage"},
+	{"fsx", "Storage"},
+	{"backup", "Storage"},
+	{"glacier", "Storage"},
+	{"ebs", "Storage"},
+	{"container registry", "Storage"},
+	{"cloudfront", "Networking"},
+	{"virtual private cloud", "Networking"},
+	{"vpc", "Networking"},
+	{"route 53", "Networking"},
+	{"elastic load balancing", "Networking"},
+	{"data transfer", "Networking"},
+	{"direct connect", "Networking"},
+	{"api gateway", "Networking"},
+	{"key management", "Security"},
+	{"secrets manager", "Security"},
+	{"guardduty", "Security"},
+	{"waf", "Security"},
+	{"security hub", "Security"},
+	{"cloudwatch", "Management and Governance"},
+	{"cloudtrail", "Management and Governance"},
+	{"config", "Management and Governance"},
+	{"systems manager", "Management and Governance"},
+	{"simple queue service", "Integration"},
+	{"simple notification service", "Integration"},
+	{"eventbridge", "Integration"},
+	{"step functions", "Integration"},
+	{"lambda", "Compute"},
+	{"elastic compute cloud", "Compute"},
+	{"ec2", "Compute"},
+	{"container service", "Compute"},
+	{"kubernetes", "Compute"},
+	{"fargate", "Compute"},
+	{"lightsail", "Compute"},
+}
+
+// ServiceCategory returns the FOCUS service category of an AWS service name
+func ServiceCategory(serviceName string) string {
+	lower := strings.ToLower(serviceName)
+	for _, entry := range serviceCategories {
+		if strings.Contains(lower, entry.keyword) {
+			return entry.category
+		}
+	}
+	return "Other"
+}
+
+// resourceServices maps inventoried resource types to the AWS service that bills them
+var resourceServices = map[models.ResourceType]string{
+	models.EC2Instance:              "Amazon Elastic Compute Cloud",
+	models.RDSInstance:              "Amazon Relational Database Service",
+	models.S3Bucket:                 "Amazon Simple Storage Service",
+	models.LambdaFunction:           "AWS Lambda",
+	models.LoadBalancer:             "Elastic Load Balancing",
+	models.NATGateway:               "Amazon Virtual Private Cloud",
+	models.DynamoDBTable:            "Amazon DynamoDB",
+	models.ElastiCacheCluster:       "Amazon ElastiCache",
+	models.OpenSearchDomain:         "Amazon OpenSearch Service",
+	models.RedshiftCluster:          "Amazon Redshift",
+	models.EKSCluster:               "Amazon Elastic Kubernetes Service",
+	models.EKSNodeGroup:             "Amazon Elastic Compute Cloud",
+	models.EKSFargateProfile:        "AWS Fargate",
+	models.ECSCluster:               "Amazon Elastic Container Service",
+	models.ECSService:               "Amazon Elastic Container Service",
+	models.EFSFileSystem:            "Amazon Elastic File System",
+	models.FSxFileSystem:            "Amazon FSx",
+	models.VPCEndpoint:              "Amazon Virtual Private Cloud",
+	models.TransitGatewayAttachment: "Amazon Virtual Private Cloud",
+	models.VPNConnection:            "Amazon Virtual Private Cloud",
+	models.EBSVolume:                "Amazon Elastic Compute Cloud",
+	models.ECRRepository:            "Amazon EC2 Container Registry",
+	models.SecretsManagerSecret:     "AWS Secrets Manager",
+	models.KMSKey:                   "AWS Key Management Service",
+	models.Route53HostedZone:        "Amazon Route 53",
+	models.CloudWatchAlarm:          "Amazon CloudWatch",
+	models.CloudWatchMetrics:        "Amazon CloudWatch",
+	models.CloudWatchLogGroup:       "Amazon CloudWatch",
+}
+
+// ServiceName returns the AWS service that bills a resource type, or the type itself when unknown
+func ServiceName(resourceType models.ResourceType) string {
+	if serviceName, ok := resourceServices[resourceType]; ok {
+		return serviceName
+	}
+	return string(resourceType)
+}
+
+// AWSRow returns a FOCUS row for an AWS charge with the provider columns filled in
+func AWSRow(accountID, serviceName string, start, end time.Time, cost float64, currency string) Row {
+	periodStart, periodEnd := BillingPeriod(start)
+	return Row{
+		BillingAccountId:   accountID,
+		SubAccountId:       accountID,
+		BillingCurrency:    currency,
+		BillingPeriodStart: periodStart,
+		BillingPeriodEnd:   periodEnd,
+		ChargePeriodStart:  start,
+		ChargePeriodEnd:    end,
+		ChargeCategory:     "Usage",
+		ChargeFrequency:    "Usage-Based",
+		BilledCost:         cost,
+		EffectiveCost:      cost,
+		ListCost:           cost,
+		ContractedCost:     cost,
+		InvoiceIssuerName:  awsProvider,
+		ProviderName:       awsProvider,
+		PublisherName:      awsProvider,
+		ServiceCategory:    ServiceCategory(serviceName),
+		ServiceName:        serviceName,
+	}
+}
+
+// FromResources returns one row per resource with its estimated cost for the current billing
+// period. The estimates use list prices, so list, contracted and effective cost are the same.
+func FromResources(resources []models.Resource, now time.Time) []Row {
+	start, end := BillingPeriod(now)
+	rows := make([]Row, 0, len(resources))
+	for _, resource := range resources {
+		row := AWSRow(resource.AccountID, ServiceName(resource.Type), start, end, resource.MonthlyCost, "USD")
+		row.ChargeDescription = fmt.Sprintf("Estimated monthly cost of %s %s", resource.Type, resource.Name)
+		row.RegionId = resource.Region
+		row.RegionName = resource.Region
+		row.ResourceId = resource.ID
+		row.ResourceName = resource.Name
+		row.ResourceType = string(resource.Type)
+
+		tags := make(map[string]string, len(resource.Tags))
+		for _, tag := range resource.Tags {
+			tags[tag.Key] = tag.Value
+		}
+		row.Tags = TagsJSON(tags)
+		rows = append(rows, row)
+	}
+	return rows
+}
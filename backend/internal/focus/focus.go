@@ -0,0 +1,179 @@
+// Package focus converts cost data to and from the FinOps Open Cost and Usage Specification
+// (FOCUS) 1.0 format, so AWS costs can be exported to FinOps tools and spend from other
+// clouds can be shown next to AWS.
+package focus
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Row is one FOCUS cost and usage row
+type Row struct {
+	BillingAccountId   string    `json:"BillingAccountId" parquet:"BillingAccountId"`
+	BillingAccountName string    `json:"BillingAccountName" parquet:"BillingAccountName,optional"`
+	SubAccountId       string    `json:"SubAccountId" parquet:"SubAccountId,optional"`
+	SubAccountName     string    `json:"SubAccountName" parquet:"SubAccountName,optional"`
+	BillingCurrency    string    `json:"BillingCurrency" parquet:"BillingCurrency"`
+	BillingPeriodStart time.Time `json:"BillingPeriodStart" parquet:"BillingPeriodStart,timestamp(millisecond)"`
+	BillingPeriodEnd   time.Time `json:"BillingPeriodEnd" parquet:"BillingPeriodEnd,timestamp(millisecond)"`
+	ChargePeriodStart  time.Time `json:"ChargePeriodStart" parquet:"ChargePeriodStart,timestamp(millisecond)"`
+	ChargePeriodEnd    time.Time `json:"ChargePeriodEnd" parquet:"ChargePeriodEnd,timestamp(millisecond)"`
+	ChargeCategory     string    `json:"ChargeCategory" parquet:"ChargeCategory"`
+	ChargeClass        string    `json:"ChargeClass" parquet:"ChargeClass,optional"`
+	ChargeDescription  string    `json:"ChargeDescription" parquet:"ChargeDescription,optional"`
+	ChargeFrequency    string    `json:"ChargeFrequency" parquet:"ChargeFrequency,optional"`
+	BilledCost         float64   `json:"BilledCost" parquet:"BilledCost"`
+	EffectiveCost      float64   `json:"EffectiveCost" parquet:"EffectiveCost"`
+	ListCost           float64   `json:"ListCost" parquet:"ListCost"`
+	ContractedCost     float64   `json:"ContractedCost" parquet:"ContractedCost"`
+	PricingQuantity    *float64  `json:"PricingQuantity" parquet:"PricingQuantity,optional"`
+	PricingUnit        string    `json:"PricingUnit" parquet:"PricingUnit,optional"`
+	InvoiceIssuerName  string    `json:"InvoiceIssuerName" parquet:"InvoiceIssuerName"`
+	ProviderName       string    `json:"ProviderName" parquet:"ProviderName"`
+	PublisherName      string    `json:"PublisherName" parquet:"PublisherName"`
+	ServiceCategory    string    `json:"ServiceCategory" parquet:"ServiceCategory"`
+	ServiceName        string    `json:"ServiceName" parquet:"ServiceName"`
+	RegionId           string    `json:"RegionId" parquet:"RegionId,optional"`
+	RegionName         string    `json:"RegionName" parquet:"RegionName,optional"`
+	ResourceId         string    `json:"ResourceId" parquet:"ResourceId,optional"`
+	ResourceName       string    `json:"ResourceName" parquet:"ResourceName,optional"`
+	ResourceType       string    `json:"ResourceType" parquet:"ResourceType,optional"`
+	Tags               string    `json:"Tags" parquet:"Tags,optional"`
+}
+
+// column reads and writes one FOCUS column as text
+type column struct {
+	name string
+	get  func(r *Row) string
+	set  func(r *Row, value string) error
+}
+
+// columns are the FOCUS columns in the order they are written
+var columns = []column{
+	stringColumn("BillingAccountId", func(r *Row) *string { return &r.BillingAccountId }),
+	stringColumn("BillingAccountName", func(r *Row) *string { return &r.BillingAccountName }),
+	stringColumn("SubAccountId", func(r *Row) *string { return &r.SubAccountId }),
+	stringColumn("SubAccountName", func(r *Row) *string { return &r.SubAccountName }),
+	stringColumn("BillingCurrency", func(r *Row) *string { return &r.BillingCurrency }),
+	timeColumn("BillingPeriodStart", func(r *Row) *time.Time { return &r.BillingPeriodStart }),
+	timeColumn("BillingPeriodEnd", func(r *Row) *time.Time { return &r.BillingPeriodEnd }),
+	timeColumn("ChargePeriodStart", func(r *Row) *time.Time { return &r.ChargePeriodStart }),
+	timeColumn("ChargePeriodEnd", func(r *Row) *time.Time { return &r.ChargePeriodEnd }),
+	stringColumn("ChargeCategory", func(r *Row) *string { return &r.ChargeCategory }),
+	stringColumn("ChargeClass", func(r *Row) *string { return &r.ChargeClass }),
+	stringColumn("ChargeDescription", func(r *Row) *string { return &r.ChargeDescription }),
+	stringColumn("ChargeFrequency", func(r *Row) *string { return &r.ChargeFrequency }),
+	floatColumn("BilledCost", func(r *Row) *float64 { return &r.BilledCost }),
+	floatColumn("EffectiveCost", func(r *Row) *float64 { return &r.EffectiveCost }),
+	floatColumn("ListCost", func(r *Row) *float64 { return &r.ListCost }),
+	floatColumn("ContractedCost", func(r *Row) *float64 { return &r.ContractedCost }),
+	{
+		name: "PricingQuantity",
+		get: func(r *Row) string {
+			if r.PricingQuantity == nil {
+				return ""
+			}
+			return strconv.FormatFloat(*r.PricingQuantity, 'f', -1, 64)
+		},
+		set: func(r *Row, value string) error {
+			if value == "" {
+				return nil
+			}
+			quantity, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return err
+			}
+			r.PricingQuantity = &quantity
+			return nil
+		},
+	},
+	stringColumn("PricingUnit", func(r *Row) *string { return &r.PricingUnit }),
+	stringColumn("InvoiceIssuerName", func(r *Row) *string { return &r.InvoiceIssuerName }),
+	stringColumn("ProviderName", func(r *Row) *string { return &r.ProviderName }),
+	stringColumn("PublisherName", func(r *Row) *string { return &r.PublisherName }),
+	stringColumn("ServiceCategory", func(r *Row) *string { return &r.ServiceCategory }),
+	stringColumn("ServiceName", func(r *Row) *string { return &r.ServiceName }),
+	stringColumn("RegionId", func(r *Row) *string { return &r.RegionId }),
+	stringColumn("RegionName", func(r *Row) *string { return &r.RegionName }),
+	stringColumn("ResourceId", func(r *Row) *string { return &r.ResourceId }),
+	stringColumn("ResourceName", func(r *Row) *string { return &r.ResourceName }),
+	stringColumn("ResourceType", func(r *Row) *string { return &r.ResourceType }),
+	stringColumn("Tags", func(r *Row) *string { return &r.Tags }),
+}
+
+// requiredColumns must be present in imported files
+var requiredColumns = []string{"BilledCost", "BillingCurrency", "ChargePeriodStart", "ChargePeriodEnd", "ProviderName", "ServiceName"}
+
+func stringColumn(name string, field func(r *Row) *string) column {
+	return column{
+		name: name,
+		get:  func(r *Row) string { return *field(r) },
+		set:  func(r *Row, value string) error { *field(r) = value; return nil },
+	}
+}
+
+func floatColumn(name string, field func(r *Row) *float64) column {
+	return column{
+		name: name,
+		get:  func(r *Row) string { return strconv.FormatFloat(*field(r), 'f', -1, 64) },
+		set: func(r *Row, value string) error {
+			if value == "" {
+				*field(r) = 0
+				return nil
+			}
+			amount, err := strconv.ParseFloat(value, 64)
+			*field(r) = amount
+			return err
+		},
+	}
+}
+
+func timeColumn(name string, field func(r *Row) *time.Time) column {
+	return column{
+		name: name,
+		get: func(r *Row) string {
+			if field(r).IsZero() {
+				return ""
+			}
+			return field(r).UTC().Format(time.RFC3339)
+		},
+		set: func(r *Row, value string) error {
+			if value == "" {
+				return nil
+			}
+			t, err := ParseTime(value)
+			*field(r) = t
+			return err
+		},
+	}
+}
+
+// ParseTime parses a FOCUS date-time. The specification requires ISO 8601 in UTC, but dates
+// and the "2006-01-02 15:04:05" form written by some tools are accepted too.
+func ParseTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date-time %q", value)
+}
+
+// TagsJSON encodes tags as the FOCUS key-value JSON object
+func TagsJSON(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(tags)
+	return string(data)
+}
+
+// BillingPeriod returns the calendar month containing t, the billing period AWS uses
+func BillingPeriod(t time.Time) (time.Time, time.Time) {
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
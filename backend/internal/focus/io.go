@@ -0,0 +1,109 @@
+package focus
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/tabular"
+)
+
+// Format is a FOCUS export format
+type Format string
+
+const (
+	CSV     Format = "csv"
+	Parquet Format = "parquet"
+)
+
+// ParseFormat reads a format name
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "", "csv":
+		return CSV, nil
+	case "parquet":
+		return Parquet, nil
+	}
+	return "", fmt.Errorf("format must be csv or parquet")
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	if f == Parquet {
+		return "application/vnd.apache.parquet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Write writes rows as a FOCUS file
+func Write(w io.Writer, format Format, rows []Row) error {
+	if format == Parquet {
+		writer := parquet.NewGenericWriter[Row](w, parquet.Compression(&parquet.Snappy))
+		if _, err := writer.Write(rows); err != nil {
+			return err
+		}
+		return writer.Close()
+	}
+
+	writer := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = c.name
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+	for i := range rows {
+		for j, c := range columns {
+			record[j] = c.get(&rows[i])
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Import reads every row of a FOCUS file. Columns outside the FOCUS subset the board uses,
+// including x_ custom columns, are ignored.
+func Import(reader tabular.Reader) ([]Row, error) {
+	present := make(map[string]bool)
+	for _, name := range reader.Columns() {
+		present[name] = true
+	}
+	var missing []string
+	for _, name := range requiredColumns {
+		if !present[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("not a FOCUS file: missing columns %s", strings.Join(missing, ", "))
+	}
+
+	var rows []Row
+	for n := 1; ; n++ {
+		values, err := reader.Next()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var row Row
+		for _, c := range columns {
+			if err := c.set(&row, values[c.name]); err != nil {
+				return nil, fmt.Errorf("row %d, %s: %w", n, c.name, err)
+			}
+		}
+		if row.ChargeCategory == "" {
+			row.ChargeCategory = "Usage"
+		}
+		rows = append(rows, row)
+	}
+}
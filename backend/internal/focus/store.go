@@ -0,0 +1,250 @@
+package focus
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/tabular"
+)
+
+// Dataset summarizes an imported FOCUS file
+type Dataset struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Providers   []string  `json:"providers"`
+	Currency    string    `json:"currency"`
+	Rows        int       `json:"rows"`
+	BilledCost  float64   `json:"billedCost"`
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+	ImportedAt  time.Time `json:"importedAt"`
+}
+
+// ServiceCost is the billed cost of one provider's service on one day
+type ServiceCost struct {
+	Provider string  `json:"provider"`
+	Service  string  `json:"service"`
+	Date     string  `json:"date"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// Store holds imported FOCUS data, optionally keeping the files in a directory so imports
+// survive restarts
+type Store struct {
+	mu       sync.RWMutex
+	dir      string
+	datasets []*dataset
+}
+
+type dataset struct {
+	Dataset
+	file string
+	rows []Row
+}
+
+// NewStore creates a store, loading every FOCUS file already in dir. An empty dir keeps
+// imports in memory only.
+func NewStore(dir string) (*Store, error) {
+	store := &Store{dir: dir}
+	if dir == "" {
+		return store, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if _, err := tabular.FormatOf(entry.Name()); err != nil {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if err := store.load(path); err != nil {
+			log.Printf("Error loading FOCUS file %s: %v", path, err)
+		}
+	}
+	return store, nil
+}
+
+// load reads a FOCUS file from the store directory. Files are named <id>-<name>.
+func (s *Store) load(path string) error {
+	reader, err := tabular.Open(path)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	rows, err := Import(reader)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	base := filepath.Base(path)
+	id, name, ok := strings.Cut(base, "-")
+	if !ok {
+		id, name = newID(), base
+	}
+	s.add(newDataset(id, name, info.ModTime(), rows), path)
+	return nil
+}
+
+// Import parses a FOCUS file and adds it to the store
+func (s *Store) Import(name string, format tabular.Format, data []byte) (Dataset, error) {
+	reader, err := tabular.Read(data, format)
+	if err != nil {
+		return Dataset{}, err
+	}
+	defer reader.Close()
+
+	rows, err := Import(reader)
+	if err != nil {
+		return Dataset{}, err
+	}
+	if len(rows) == 0 {
+		return Dataset{}, fmt.Errorf("FOCUS file has no rows")
+	}
+
+	name = filepath.Base(name)
+	imported := newDataset(newID(), name, time.Now(), rows)
+	path := ""
+	if s.dir != "" {
+		path = filepath.Join(s.dir, imported.ID+"-"+name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			log.Printf("Error saving FOCUS file %s: %v", path, err)
+			return Dataset{}, err
+		}
+	}
+	s.add(imported, path)
+	return imported.Dataset, nil
+}
+
+// Datasets returns the imported datasets, newest first
+func (s *Store) Datasets() []Dataset {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	datasets := make([]Dataset, 0, len(s.datasets))
+	for i := len(s.datasets) - 1; i >= 0; i-- {
+		datasets = append(datasets, s.datasets[i].Dataset)
+	}
+	return datasets
+}
+
+// Delete removes an imported dataset and its file, reporting whether it existed
+func (s *Store) Delete(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, d := range s.datasets {
+		if d.ID != id {
+			continue
+		}
+		if d.file != "" {
+			if err := os.Remove(d.file); err != nil {
+				log.Printf("Error removing FOCUS file %s: %v", d.file, err)
+			}
+		}
+		s.datasets = append(s.datasets[:i], s.datasets[i+1:]...)
+		return true
+	}
+	return false
+}
+
+// Rows returns the imported rows whose charge period starts in [start, end)
+func (s *Store) Rows(start, end time.Time) []Row {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var rows []Row
+	for _, d := range s.datasets {
+		for _, row := range d.rows {
+			if !row.ChargePeriodStart.Before(start) && row.ChargePeriodStart.Before(end) {
+				rows = append(rows, row)
+			}
+		}
+	}
+	return rows
+}
+
+// CostByService totals the imported billed cost by provider, service and day for charges
+// starting in [start, end)
+func (s *Store) CostByService(start, end time.Time) []ServiceCost {
+	type key struct{ provider, service, date, currency string }
+	totals := make(map[key]float64)
+	for _, row := range s.Rows(start, end) {
+		k := key{row.ProviderName, row.ServiceName, row.ChargePeriodStart.Format("2006-01-02"), row.BillingCurrency}
+		totals[k] += row.BilledCost
+	}
+
+	costs := make([]ServiceCost, 0, len(totals))
+	for k, amount := range totals {
+		costs = append(costs, ServiceCost{Provider: k.provider, Service: k.service, Date: k.date, Amount: amount, Currency: k.currency})
+	}
+	sort.Slice(costs, func(i, j int) bool {
+		if costs[i].Date != costs[j].Date {
+			return costs[i].Date < costs[j].Date
+		}
+		if costs[i].Provider != costs[j].Provider {
+			return costs[i].Provider < costs[j].Provider
+		}
+		return costs[i].Service < costs[j].Service
+	})
+	return costs
+}
+
+func (s *Store) add(d *dataset, file string) {
+	d.file = file
+	s.mu.Lock()
+	s.datasets = append(s.datasets, d)
+	s.mu.Unlock()
+}
+
+// newDataset summarizes imported rows
+func newDataset(id, name string, importedAt time.Time, rows []Row) *dataset {
+	d := &dataset{
+		Dataset: Dataset{ID: id, Name: name, Rows: len(rows), ImportedAt: importedAt, Providers: make([]string, 0)},
+		rows:    rows,
+	}
+	providers := make(map[string]bool)
+	for _, row := range rows {
+		d.BilledCost += row.BilledCost
+		if d.Currency == "" {
+			d.Currency = row.BillingCurrency
+		}
+		if !providers[row.ProviderName] {
+			providers[row.ProviderName] = true
+			d.Providers = append(d.Providers, row.ProviderName)
+		}
+		if d.PeriodStart.IsZero() || row.ChargePeriodStart.Before(d.PeriodStart) {
+			d.PeriodStart = row.ChargePeriodStart
+		}
+		if row.ChargePeriodEnd.After(d.PeriodEnd) {
+			d.PeriodEnd = row.ChargePeriodEnd
+		}
+	}
+	sort.Strings(d.Providers)
+	return d
+}
+
+// newID returns a random dataset ID
+func newID() string {
+	b := make([]byte, 6)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
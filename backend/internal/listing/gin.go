@@ -0,0 +1,76 @@
+package listing
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Response headers carrying page metadata, so list endpoints keep returning plain arrays
+const (
+	HeaderTotalCount       = "X-Total-Count"
+	HeaderNextCursor       = "X-Next-Cursor"
+	HeaderTotalMonthlyCost = "X-Total-Monthly-Cost"
+)
+
+// Headers lists the page metadata headers for CORS exposure
+var Headers = []string{HeaderTotalCount, HeaderNextCursor, HeaderTotalMonthlyCost}
+
+// Respond applies the request's list query to items and writes the resulting page as JSON.
+// Query parameters named in skip are left to the handler. Filtering or sorting on a field the
+// records do not have is a bad request.
+func Respond[T any](c *gin.Context, items []T, skip ...string) {
+	opts, err := parseFor[T](c, skip)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := Apply(items, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeHeaders(c, page.Total, page.TotalMonthlyCost, page.NextCursor)
+	c.JSON(http.StatusOK, page.Items)
+}
+
+// writeHeaders writes the page metadata headers
+func writeHeaders(c *gin.Context, total int, totalMonthlyCost float64, nextCursor string) {
+	c.Header(HeaderTotalCount, strconv.Itoa(total))
+	c.Header(HeaderTotalMonthlyCost, strconv.FormatFloat(totalMonthlyCost, 'f', 2, 64))
+	if nextCursor != "" {
+		c.Header(HeaderNextCursor, nextCursor)
+	}
+}
+
+// parseFor parses the request's list query and rejects fields that records of type T do not have
+func parseFor[T any](c *gin.Context, skip []string) (Options, error) {
+	opts, err := Parse(c.Request.URL.Query(), skip...)
+	if err != nil {
+		return opts, err
+	}
+	if unknown := opts.unknownFields(reflect.TypeFor[T]()); len(unknown) > 0 {
+		return opts, unknownFieldError(unknown)
+	}
+	return opts, nil
+}
+
+// FilterQuery applies the request's filters, search and sort to items without paginating,
+// for endpoints that return several lists at once
+func FilterQuery[T any](c *gin.Context, items []T, skip ...string) ([]T, error) {
+	opts, err := parseFor[T](c, skip)
+	if err != nil {
+		return nil, err
+	}
+	opts.Limit, opts.After = 0, nil
+
+	page, err := Apply(items, opts)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
@@ -0,0 +1,112 @@
+package listing
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Group pages several lists returned together by one endpoint. ?limit= applies to each list and
+// the shared cursor holds each list's position; lists missing from it were exhausted on an
+// earlier page. Totals cover every list.
+type Group struct {
+	values  url.Values
+	after   map[string]string
+	resumed bool
+	next    map[string]string
+
+	lists            int
+	unknown          map[string]int
+	total            int
+	totalMonthlyCost float64
+}
+
+// NewGroup starts a group for the given query parameters
+func NewGroup(values url.Values) (*Group, error) {
+	g := &Group{values: url.Values{}, next: map[string]string{}, unknown: map[string]int{}}
+	for key, value := range values {
+		if key != "cursor" {
+			g.values[key] = value
+		}
+	}
+	if raw := values.Get("cursor"); raw != "" {
+		data, err := base64.RawURLEncoding.DecodeString(raw)
+		if err != nil || json.Unmarshal(data, &g.after) != nil {
+			return nil, fmt.Errorf("invalid cursor")
+		}
+		g.resumed = true
+	}
+	return g, nil
+}
+
+// GroupApply applies the query to one list of the group and returns its page. Query parameters
+// named in skip are left to the handler.
+func GroupApply[T any](g *Group, name string, items []T, skip ...string) ([]T, error) {
+	opts, err := Parse(g.values, skip...)
+	if err != nil {
+		return nil, err
+	}
+	g.lists++
+	for _, field := range opts.unknownFields(reflect.TypeFor[T]()) {
+		g.unknown[field]++
+	}
+
+	raw, pending := g.after[name]
+	if g.resumed {
+		if opts.Limit == 0 {
+			opts.Limit = DefaultLimit
+		}
+		if pending {
+			cursor, err := decodeCursor(raw)
+			if err != nil || len(cursor.Values) != len(opts.Sort) {
+				return nil, fmt.Errorf("invalid cursor")
+			}
+			if cursor.Sort != opts.sortSpec() {
+				return nil, fmt.Errorf("cursor was issued for a different sort; start again without it")
+			}
+			opts.After = &cursor
+		}
+	}
+
+	page, err := Apply(items, opts)
+	if err != nil {
+		return nil, err
+	}
+	g.total += page.Total
+	g.totalMonthlyCost += page.TotalMonthlyCost
+	if g.resumed && !pending {
+		return []T{}, nil
+	}
+	if page.NextCursor != "" {
+		g.next[name] = page.NextCursor
+	}
+	return page.Items, nil
+}
+
+// Finish rejects fields that none of the lists have and writes the page metadata headers for
+// the whole group
+func (g *Group) Finish(c *gin.Context) error {
+	var unknown []string
+	for field, lists := range g.unknown {
+		if lists == g.lists {
+			unknown = append(unknown, field)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return unknownFieldError(unknown)
+	}
+
+	var next string
+	if len(g.next) > 0 {
+		data, _ := json.Marshal(g.next)
+		next = base64.RawURLEncoding.EncodeToString(data)
+	}
+	writeHeaders(c, g.total, g.totalMonthlyCost, next)
+	return nil
+}
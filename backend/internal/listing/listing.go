@@ -0,0 +1,485 @@
+// Package listing applies a consistent filter, search, sort and pagination layer to the
+// list endpoints of both API servers.
+package listing
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultLimit is the page size used when ?cursor= is given without ?limit=
+	DefaultLimit = 100
+	// MaxLimit is the largest page size a client may ask for
+	MaxLimit = 1000
+)
+
+// reservedParams are query parameters that control the listing instead of filtering a field
+var reservedParams = map[string]bool{
+	"search": true,
+	"q":      true,
+	"sort":   true,
+	"limit":  true,
+	"cursor": true,
+	"fresh":  true,
+}
+
+// fieldAliases lists the fields tried, in order, when filtering or sorting on a field name
+var fieldAliases = map[string][]string{
+	"state":  {"state", "status"},
+	"status": {"status", "state"},
+}
+
+// identityFields are tried in order for the field that tells records apart. Records with equal
+// sort values are ordered by it, so a cursor resumes at the right record.
+var identityFields = []string{"id", "arn", "publicIp", "name"}
+
+// Filter matches records whose field equals any of the values
+type Filter struct {
+	Field  string
+	Values []string
+}
+
+// SortKey orders records by a field, descending when Desc is set
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// Options is a parsed list query
+type Options struct {
+	Filters []Filter
+	Tags    []Filter
+	Search  string
+	Sort    []SortKey
+	Limit   int
+	// After is the position of the last item of the previous page, read from ?cursor=
+	After *Cursor
+}
+
+// Cursor is the position of the last item of a page: its sort values and identity. Resuming after
+// it rather than at an offset keeps pages from skipping or repeating items when the list changes
+// between requests.
+type Cursor struct {
+	Sort   string `json:"s"`
+	Values []any  `json:"v"`
+	ID     string `json:"id"`
+}
+
+// Page is one page of a filtered and sorted list
+type Page[T any] struct {
+	Items            []T
+	Total            int
+	NextCursor       string
+	TotalMonthlyCost float64
+}
+
+// Parse reads list options from query parameters. Any parameter that is not reserved and not
+// in skip filters the record field of the same JSON name; tag:<key>= filters on a tag value.
+func Parse(values url.Values, skip ...string) (Options, error) {
+	skipped := make(map[string]bool, len(skip))
+	for _, name := range skip {
+		skipped[name] = true
+	}
+
+	var opts Options
+	for name, raw := range values {
+		if reservedParams[name] || skipped[name] || strings.HasPrefix(name, "_") {
+			continue
+		}
+		var matches []string
+		for _, value := range raw {
+			for _, part := range strings.Split(value, ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					matches = append(matches, part)
+				}
+			}
+		}
+		if len(matches) == 0 || (name == "state" && len(matches) == 1 && matches[0] == "all") {
+			continue
+		}
+		if key, ok := strings.CutPrefix(name, "tag:"); ok {
+			opts.Tags = append(opts.Tags, Filter{Field: key, Values: matches})
+			continue
+		}
+		opts.Filters = append(opts.Filters, Filter{Field: name, Values: matches})
+	}
+
+	opts.Search = strings.ToLower(strings.TrimSpace(values.Get("search")))
+	if opts.Search == "" {
+		opts.Search = strings.ToLower(strings.TrimSpace(values.Get("q")))
+	}
+
+	for _, field := range strings.Split(values.Get("sort"), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		key := SortKey{Field: strings.TrimLeft(field, "+-"), Desc: strings.HasPrefix(field, "-")}
+		opts.Sort = append(opts.Sort, key)
+	}
+
+	if raw := values.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return opts, fmt.Errorf("limit must be an integer between 1 and %d", MaxLimit)
+		}
+		opts.Limit = limit
+	}
+
+	if raw := values.Get("cursor"); raw != "" {
+		cursor, err := decodeCursor(raw)
+		if err != nil || len(cursor.Values) != len(opts.Sort) {
+			return opts, fmt.Errorf("invalid cursor")
+		}
+		if cursor.Sort != opts.sortSpec() {
+			return opts, fmt.Errorf("cursor was issued for a different sort; start again without it")
+		}
+		opts.After = &cursor
+		if opts.Limit == 0 {
+			opts.Limit = DefaultLimit
+		}
+	}
+
+	return opts, nil
+}
+
+// sortSpec renders the sort keys the way ?sort= takes them
+func (o Options) sortSpec() string {
+	keys := make([]string, len(o.Sort))
+	for i, key := range o.Sort {
+		keys[i] = key.Field
+		if key.Desc {
+			keys[i] = "-" + key.Field
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// position returns where a record sits in the sort order
+func (o Options) position(fields map[string]any) Cursor {
+	position := Cursor{Sort: o.sortSpec(), Values: make([]any, len(o.Sort))}
+	for i, key := range o.Sort {
+		position.Values[i] = lookup(fields, key.Field)
+	}
+	for _, name := range identityFields {
+		if id := text(fields[name]); id != "" {
+			position.ID = id
+			break
+		}
+	}
+	return position
+}
+
+// comparePositions orders two positions by the sort keys, then by identity
+func (o Options) comparePositions(a, b Cursor) int {
+	for i, key := range o.Sort {
+		cmp := compare(a.Values[i], b.Values[i])
+		if key.Desc {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+// unknownFields returns the filter and sort fields that records of type t do not have. They would
+// otherwise match nothing, or leave the order unchanged, without saying so. Only struct records
+// are checked.
+func (o Options) unknownFields(t reflect.Type) []string {
+	known := jsonFields(t)
+	if known == nil {
+		return nil
+	}
+	has := func(name string) bool {
+		candidates := fieldAliases[name]
+		if candidates == nil {
+			candidates = []string{name}
+		}
+		for _, candidate := range candidates {
+			if known[strings.ToLower(candidate)] {
+				return true
+			}
+		}
+		return false
+	}
+
+	var unknown []string
+	for _, filter := range o.Filters {
+		if !has(filter.Field) {
+			unknown = append(unknown, filter.Field)
+		}
+	}
+	for _, filter := range o.Tags {
+		if !known["tags"] {
+			unknown = append(unknown, "tag:"+filter.Field)
+		}
+	}
+	for _, key := range o.Sort {
+		if !has(key.Field) {
+			unknown = append(unknown, key.Field)
+		}
+	}
+	return unknown
+}
+
+// unknownFieldError reports fields that no record has
+func unknownFieldError(fields []string) error {
+	if len(fields) == 1 {
+		return fmt.Errorf("unknown field %q", fields[0])
+	}
+	return fmt.Errorf("unknown fields %q", fields)
+}
+
+// jsonFields returns the lower-cased JSON names of a struct type's fields, including those of
+// embedded structs, or nil for other types
+func jsonFields(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	fields := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			for embedded := range jsonFields(field.Type) {
+				fields[embedded] = true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = true
+	}
+	return fields
+}
+
+// Apply filters, searches, sorts and paginates items. Without a limit every match is returned in
+// its original order unless sorted; pages are ordered by identity after the sort keys.
+func Apply[T any](items []T, opts Options) (Page[T], error) {
+	type record struct {
+		item     T
+		fields   map[string]any
+		position Cursor
+	}
+
+	matched := make([]record, 0, len(items))
+	for _, item := range items {
+		fields, err := fieldsOf(item)
+		if err != nil {
+			return Page[T]{}, err
+		}
+		if opts.matches(fields) {
+			matched = append(matched, record{item: item, fields: fields, position: opts.position(fields)})
+		}
+	}
+
+	if len(opts.Sort) > 0 || opts.Limit > 0 {
+		sort.SliceStable(matched, func(i, j int) bool {
+			return opts.comparePositions(matched[i].position, matched[j].position) < 0
+		})
+	}
+
+	page := Page[T]{Total: len(matched), Items: make([]T, 0)}
+	for _, r := range matched {
+		if cost, ok := r.fields["monthlyCost"].(float64); ok {
+			page.TotalMonthlyCost += cost
+		}
+	}
+
+	start, end := 0, len(matched)
+	if opts.Limit > 0 {
+		if opts.After != nil {
+			start = sort.Search(len(matched), func(i int) bool {
+				return opts.comparePositions(matched[i].position, *opts.After) > 0
+			})
+		}
+		end = min(start+opts.Limit, len(matched))
+		if end < len(matched) {
+			page.NextCursor = encodeCursor(matched[end-1].position)
+		}
+	}
+	for _, r := range matched[start:end] {
+		page.Items = append(page.Items, r.item)
+	}
+
+	return page, nil
+}
+
+// matches reports whether a record passes every filter and the free-text search
+func (o Options) matches(fields map[string]any) bool {
+	for _, filter := range o.Filters {
+		if !matchesAny(text(lookup(fields, filter.Field)), filter.Values) {
+			return false
+		}
+	}
+	for _, filter := range o.Tags {
+		value, ok := tagValue(fields["tags"], filter.Field)
+		if !ok || !matchesAny(value, filter.Values) {
+			return false
+		}
+	}
+	if o.Search != "" {
+		found := false
+		for _, field := range []string{"name", "id", "arn", "publicIp"} {
+			if strings.Contains(strings.ToLower(text(fields[field])), o.Search) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldsOf flattens an item into its JSON fields so every record type is queried the same way
+func fieldsOf(item any) (map[string]any, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// lookup returns a field by JSON name, trying aliases and ignoring case
+func lookup(fields map[string]any, name string) any {
+	candidates := fieldAliases[name]
+	if candidates == nil {
+		candidates = []string{name}
+	}
+	for _, candidate := range candidates {
+		if value, ok := fields[candidate]; ok {
+			return value
+		}
+		for key, value := range fields {
+			if strings.EqualFold(key, candidate) {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+// tagValue finds a tag in either a [{key, value}] list or a key/value object
+func tagValue(tags any, key string) (string, bool) {
+	switch tags := tags.(type) {
+	case []any:
+		for _, tag := range tags {
+			if tag, ok := tag.(map[string]any); ok && text(tag["key"]) == key {
+				return text(tag["value"]), true
+			}
+		}
+	case map[string]any:
+		if value, ok := tags[key]; ok {
+			return text(value), true
+		}
+	}
+	return "", false
+}
+
+// matchesAny reports whether value equals one of the wanted values, ignoring case
+func matchesAny(value string, wanted []string) bool {
+	for _, w := range wanted {
+		if strings.EqualFold(value, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// text renders a JSON value for comparison with a query parameter
+func text(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// compare orders two JSON values: numbers numerically, timestamps chronologically, everything
+// else as case-insensitive text. Missing values sort last.
+func compare(a, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		default:
+			return -1
+		}
+	}
+	if x, ok := a.(float64); ok {
+		if y, ok := b.(float64); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	if x, ok := a.(bool); ok {
+		if y, ok := b.(bool); ok && x != y {
+			if !x {
+				return -1
+			}
+			return 1
+		}
+	}
+	as, bs := text(a), text(b)
+	if x, err := time.Parse(time.RFC3339Nano, as); err == nil {
+		if y, err := time.Parse(time.RFC3339Nano, bs); err == nil {
+			return x.Compare(y)
+		}
+	}
+	return strings.Compare(strings.ToLower(as), strings.ToLower(bs))
+}
+
+// encodeCursor makes an opaque cursor for a position
+func encodeCursor(position Cursor) string {
+	data, _ := json.Marshal(position)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor reads a position back out of a cursor
+func decodeCursor(cursor string) (Cursor, error) {
+	var position Cursor
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return position, err
+	}
+	err = json.Unmarshal(data, &position)
+	return position, err
+}
@@ -0,0 +1,171 @@
+package listing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type item struct {
+	ID          string  `json:"id"`
+	State       string  `json:"state"`
+	MonthlyCost float64 `json:"monthlyCost"`
+}
+
+type logGroup struct {
+	Name string `json:"name"`
+}
+
+// pages reads every page of items for the query and returns the IDs in order
+func pages(t *testing.T, items []item, query string, between func(page int) []item) []string {
+	t.Helper()
+	values, _ := url.ParseQuery(query)
+	var ids []string
+	for page := 0; page < 10; page++ {
+		opts, err := Parse(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := Apply(items, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Total != len(items) {
+			t.Errorf("page %d total = %d, want %d", page, result.Total, len(items))
+		}
+		for _, i := range result.Items {
+			ids = append(ids, i.ID)
+		}
+		if result.NextCursor == "" {
+			return ids
+		}
+		values.Set("cursor", result.NextCursor)
+		if between != nil {
+			items = between(page)
+		}
+	}
+	t.Fatal("pagination did not end")
+	return nil
+}
+
+func TestCursorResumesAfterLastItem(t *testing.T) {
+	items := []item{
+		{ID: "d", MonthlyCost: 5}, {ID: "a", MonthlyCost: 5}, {ID: "c", MonthlyCost: 9},
+		{ID: "b", MonthlyCost: 5}, {ID: "e", MonthlyCost: 1},
+	}
+
+	// Equal costs are ordered by ID, so no item is repeated or skipped
+	if ids := pages(t, items, "sort=-monthlyCost&limit=2", nil); !slices.Equal(ids, []string{"c", "a", "b", "d", "e"}) {
+		t.Errorf("ids = %v", ids)
+	}
+
+	// Items added before the position after the first page do not shift the next pages
+	grown := append([]item{{ID: "0", MonthlyCost: 7}}, items...)
+	ids := pages(t, items, "sort=-monthlyCost&limit=2", func(int) []item { return grown })
+	if !slices.Equal(ids, []string{"c", "a", "b", "d", "e"}) {
+		t.Errorf("ids after insertion = %v", ids)
+	}
+}
+
+func TestCursorRejectsOtherSort(t *testing.T) {
+	items := []item{{ID: "a"}, {ID: "b"}}
+	opts, _ := Parse(url.Values{"sort": {"id"}, "limit": {"1"}})
+	page, _ := Apply(items, opts)
+	if _, err := Parse(url.Values{"sort": {"-id"}, "limit": {"1"}, "cursor": {page.NextCursor}}); err == nil {
+		t.Error("cursor issued for sort=id accepted with sort=-id")
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	for _, test := range []struct {
+		query   string
+		unknown []string
+	}{
+		{"status=running", nil},
+		{"sort=-monthlyCost", nil},
+		{"region=us-east-1", []string{"region"}},
+		{"tag:env=prod", []string{"tag:env"}},
+		{"sort=launchTime", []string{"launchTime"}},
+	} {
+		values, _ := url.ParseQuery(test.query)
+		opts, err := Parse(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if unknown := opts.unknownFields(reflect.TypeFor[item]()); !slices.Equal(unknown, test.unknown) {
+			t.Errorf("%s: unknown = %v, want %v", test.query, unknown, test.unknown)
+		}
+	}
+}
+
+func TestRespondRejectsUnknownFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?region=us-east-1", nil)
+
+	Respond(c, []item{{ID: "a"}})
+	if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), `unknown field \"region\"`) {
+		t.Errorf("response = %d %s", recorder.Code, recorder.Body)
+	}
+}
+
+func TestGroupPagesEachList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	items := []item{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	groups := []logGroup{{Name: "x"}}
+
+	values := url.Values{"limit": {"2"}}
+	var ids, names []string
+	for page := 0; page < 3; page++ {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		group, err := NewGroup(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotItems, err := GroupApply(group, "items", items)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotGroups, err := GroupApply(group, "groups", groups)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := group.Finish(c); err != nil {
+			t.Fatal(err)
+		}
+		for _, i := range gotItems {
+			ids = append(ids, i.ID)
+		}
+		for _, g := range gotGroups {
+			names = append(names, g.Name)
+		}
+		if total := recorder.Header().Get(HeaderTotalCount); total != "4" {
+			t.Errorf("page %d total = %s, want 4", page, total)
+		}
+		next := recorder.Header().Get(HeaderNextCursor)
+		if next == "" {
+			break
+		}
+		values.Set("cursor", next)
+	}
+	if !slices.Equal(ids, []string{"a", "b", "c"}) || !slices.Equal(names, []string{"x"}) {
+		t.Errorf("ids = %v, names = %v", ids, names)
+	}
+
+	// A field is unknown only when no list has it
+	group, _ := NewGroup(url.Values{"name": {"x"}, "region": {"eu-west-1"}})
+	GroupApply(group, "items", items)
+	GroupApply(group, "groups", groups)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	if err := group.Finish(c); err == nil || err.Error() != `unknown field "region"` {
+		t.Errorf("Finish error = %v", err)
+	}
+}
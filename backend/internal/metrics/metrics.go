@@ -0,0 +1,107 @@
+// Package metrics writes metric families in the Prometheus text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the MIME type of the text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Metric types
+const (
+	Gauge   = "gauge"
+	Counter = "counter"
+)
+
+// Family is a named metric with one sample per label set
+type Family struct {
+	Name    string
+	Help    string
+	Type    string
+	Samples []Sample
+}
+
+// Sample is one value of a family
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Add appends a sample with labels given as name, value pairs
+func (f *Family) Add(value float64, labels ...string) {
+	sample := Sample{Value: value}
+	if len(labels) > 0 {
+		sample.Labels = make(map[string]string, len(labels)/2)
+		for i := 0; i+1 < len(labels); i += 2 {
+			sample.Labels[labels[i]] = labels[i+1]
+		}
+	}
+	f.Samples = append(f.Samples, sample)
+}
+
+// Write writes families in the text exposition format. Samples are sorted by their labels so
+// the output is stable between scrapes.
+func Write(w io.Writer, families []Family) error {
+	out := bufio.NewWriter(w)
+	for _, family := range families {
+		out.WriteString("# HELP " + family.Name + " " + helpReplacer.Replace(family.Help) + "\n")
+		out.WriteString("# TYPE " + family.Name + " " + family.Type + "\n")
+
+		lines := make([]string, 0, len(family.Samples))
+		for _, sample := range family.Samples {
+			lines = append(lines, family.Name+formatLabels(sample.Labels)+" "+formatValue(sample.Value)+"\n")
+		}
+		sort.Strings(lines)
+		for _, line := range lines {
+			out.WriteString(line)
+		}
+	}
+	return out.Flush()
+}
+
+var (
+	helpReplacer  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+// formatLabels formats a label set as {name="value",...} in name order
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(name + `="` + labelReplacer.Replace(labels[name]) + `"`)
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// formatValue formats a sample value, spelling infinities the way Prometheus expects
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
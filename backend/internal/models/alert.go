@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Alert is raised when a refresh finds a flag that a resource did not have before
+type Alert struct {
+	ResourceID   string       `json:"resourceId"`
+	ResourceName string       `json:"resourceName"`
+	ResourceType ResourceType `json:"resourceType"`
+	Region       string       `json:"region"`
+	Code         string       `json:"code"`
+	Message      string       `json:"message"`
+	RaisedAt     time.Time    `json:"raisedAt"`
+}
+
+// ResourceChanges lists the resources a refresh added, changed or removed
+type ResourceChanges struct {
+	JobID   string     `json:"jobId"`
+	Added   []Resource `json:"added"`
+	Changed []Resource `json:"changed"`
+	Removed []string   `json:"removed"`
+}
+
+// RefreshProgress reports a collector finishing within a refresh job
+type RefreshProgress struct {
+	JobID     string       `json:"jobId"`
+	Collector CollectorRun `json:"collector"`
+	Completed int          `json:"completed"`
+	Total     int          `json:"total"`
+}
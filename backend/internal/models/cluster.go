@@ -0,0 +1,24 @@
+package models
+
+// ManagedClusterDetails holds the details shared by ElastiCache, OpenSearch and Redshift clusters
+type ManagedClusterDetails struct {
+	ARN                string      `json:"arn"`
+	Engine             string      `json:"engine"`
+	EngineVersion      string      `json:"engineVersion"`
+	NodeGroups         []NodeGroup `json:"nodeGroups"`
+	StorageGB          int32       `json:"storageGb"`
+	PriceKnown         bool        `json:"priceKnown"`
+	IdleMetric         string      `json:"idleMetric"`
+	IdleMetricValue    float64     `json:"idleMetricValue"`
+	IdleMetricKnown    bool        `json:"idleMetricKnown"`
+	ComputeMonthlyCost float64     `json:"computeMonthlyCost"`
+	StorageMonthlyCost float64     `json:"storageMonthlyCost"`
+}
+
+// NodeGroup describes a set of identical nodes in a managed cluster
+type NodeGroup struct {
+	Role       string  `json:"role"`
+	NodeType   string  `json:"nodeType"`
+	Count      int32   `json:"count"`
+	HourlyCost float64 `json:"hourlyCost"`
+}
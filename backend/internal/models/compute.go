@@ -0,0 +1,78 @@
+package models
+
+// EC2InstanceDetails holds the EC2-specific details of an instance resource
+type EC2InstanceDetails struct {
+	InstanceType     string `json:"instanceType"`
+	AvailabilityZone string `json:"availabilityZone"`
+	PrivateIP        string `json:"privateIp"`
+	PublicIP         string `json:"publicIp"`
+	Lifecycle        string `json:"lifecycle"`
+	PriceKnown       bool   `json:"priceKnown"`
+}
+
+// EKSClusterDetails holds the details of an EKS cluster
+type EKSClusterDetails struct {
+	ARN                     string  `json:"arn"`
+	Version                 string  `json:"version"`
+	PlatformVersion         string  `json:"platformVersion"`
+	NodeGroups              int     `json:"nodeGroups"`
+	FargateProfiles         int     `json:"fargateProfiles"`
+	ControlPlaneMonthlyCost float64 `json:"controlPlaneMonthlyCost"`
+}
+
+// EKSNodeGroupDetails holds the details of an EKS managed node group.
+// The group's compute cost is carried by its EC2 instances, which reference it as their parent.
+type EKSNodeGroupDetails struct {
+	ARN               string   `json:"arn"`
+	ClusterName       string   `json:"clusterName"`
+	InstanceTypes     []string `json:"instanceTypes"`
+	CapacityType      string   `json:"capacityType"`
+	DesiredSize       int32    `json:"desiredSize"`
+	MinSize           int32    `json:"minSize"`
+	MaxSize           int32    `json:"maxSize"`
+	AutoScalingGroups []string `json:"autoScalingGroups"`
+}
+
+// EKSFargateProfileDetails holds the details of an EKS Fargate profile
+type EKSFargateProfileDetails struct {
+	ARN         string   `json:"arn"`
+	ClusterName string   `json:"clusterName"`
+	Namespaces  []string `json:"namespaces"`
+}
+
+// ECSClusterDetails holds the details of an ECS cluster
+type ECSClusterDetails struct {
+	ARN                   string   `json:"arn"`
+	ActiveServices        int32    `json:"activeServices"`
+	RunningTasks          int32    `json:"runningTasks"`
+	ContainerInstanceIDs  []string `json:"containerInstanceIds"`
+	ContainerInstanceCost float64  `json:"containerInstanceCost"`
+}
+
+// ECSServiceDetails holds the details of an ECS service.
+// Fargate services are charged directly; services on EC2 are allocated a share of the
+// cluster's container instance cost by reserved CPU, which is reported but not added to
+// the service cost so instances are not counted twice.
+type ECSServiceDetails struct {
+	ARN                  string  `json:"arn"`
+	ClusterName          string  `json:"clusterName"`
+	LaunchType           string  `json:"launchType"`
+	TaskDefinition       string  `json:"taskDefinition"`
+	CPU                  int     `json:"cpu"`
+	MemoryMB             int     `json:"memoryMb"`
+	DesiredCount         int32   `json:"desiredCount"`
+	RunningCount         int32   `json:"runningCount"`
+	AllocatedMonthlyCost float64 `json:"allocatedMonthlyCost"`
+}
+
+// ComputeGroup aggregates the cost of a resource and everything beneath it, such as
+// an EKS cluster with its node groups and instances or an ECS cluster with its services
+type ComputeGroup struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name"`
+	Type             ResourceType   `json:"type"`
+	MonthlyCost      float64        `json:"monthlyCost"`
+	TotalMonthlyCost float64        `json:"totalMonthlyCost"`
+	ResourceCount    int            `json:"resourceCount"`
+	Children         []ComputeGroup `json:"children"`
+}
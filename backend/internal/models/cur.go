@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// CURFile is a Cost and Usage Report file that has been ingested
+type CURFile struct {
+	Key           string    `json:"key"`
+	Size          int64     `json:"size"`
+	Modified      time.Time `json:"modified"`
+	LineItems     int       `json:"lineItems"`
+	ResourceIDs   int       `json:"resourceIds"`
+	UnblendedCost float64   `json:"unblendedCost"`
+	Currency      string    `json:"currency"`
+	PeriodStart   time.Time `json:"periodStart"`
+	PeriodEnd     time.Time `json:"periodEnd"`
+	IngestedAt    time.Time `json:"ingestedAt"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// CURStatus describes the configured CUR source and what has been ingested from it
+type CURStatus struct {
+	Enabled  bool       `json:"enabled"`
+	Source   string     `json:"source"`
+	Files    []CURFile  `json:"files"`
+	LastSync *time.Time `json:"lastSync"`
+	// LatestDay is the last complete UTC day covered by the ingested files, used for DailyCost
+	LatestDay *time.Time `json:"latestDay"`
+}
+
+// CostPoint is the cost of a resource over one hour or day
+type CostPoint struct {
+	Start time.Time `json:"start"`
+	Cost  float64   `json:"cost"`
+}
+
+// ResourceCost is the cost of a resource over a period, from CUR line items
+type ResourceCost struct {
+	ResourceID  string      `json:"resourceId"`
+	Granularity string      `json:"granularity"`
+	Start       time.Time   `json:"start"`
+	End         time.Time   `json:"end"`
+	Total       float64     `json:"total"`
+	Currency    string      `json:"currency"`
+	Points      []CostPoint `json:"points"`
+}
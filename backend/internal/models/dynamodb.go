@@ -0,0 +1,23 @@
+package models
+
+// DynamoDBTableDetails holds the DynamoDB-specific details of a table resource
+type DynamoDBTableDetails struct {
+	ARN                     string  `json:"arn"`
+	BillingMode             string  `json:"billingMode"`
+	ProvisionedRCU          int64   `json:"provisionedRcu"`
+	ProvisionedWCU          int64   `json:"provisionedWcu"`
+	SizeBytes               int64   `json:"sizeBytes"`
+	ItemCount               int64   `json:"itemCount"`
+	GlobalSecondaryIndexes  int     `json:"globalSecondaryIndexes"`
+	TTLEnabled              bool    `json:"ttlEnabled"`
+	PITREnabled             bool    `json:"pitrEnabled"`
+	MonthlyConsumedRCU      float64 `json:"monthlyConsumedRcu"`
+	MonthlyConsumedWCU      float64 `json:"monthlyConsumedWcu"`
+	PeakConsumedRCUPerSec   float64 `json:"peakConsumedRcuPerSec"`
+	PeakConsumedWCUPerSec   float64 `json:"peakConsumedWcuPerSec"`
+	UsageKnown              bool    `json:"usageKnown"`
+	OnDemandMonthlyCost     float64 `json:"onDemandMonthlyCost"`
+	ProvisionedMonthlyCost  float64 `json:"provisionedMonthlyCost"`
+	RecommendedBillingMode  string  `json:"recommendedBillingMode"`
+	EstimatedMonthlySavings float64 `json:"estimatedMonthlySavings"`
+}
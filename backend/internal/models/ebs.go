@@ -0,0 +1,13 @@
+package models
+
+// EBSVolumeDetails holds the details of an EBS volume
+type EBSVolumeDetails struct {
+	VolumeType       string `json:"volumeType"`
+	SizeGiB          int32  `json:"sizeGiB"`
+	SizeBytes        int64  `json:"sizeBytes"`
+	Iops             int32  `json:"iops"`
+	Throughput       int32  `json:"throughput"`
+	AvailabilityZone string `json:"availabilityZone"`
+	Encrypted        bool   `json:"encrypted"`
+	AttachedTo       string `json:"attachedTo"`
+}
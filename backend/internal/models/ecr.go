@@ -0,0 +1,26 @@
+package models
+
+// ECRRepositoryDetails holds the details of an ECR repository.
+// Sizes are the sum of compressed image sizes, so layers shared between images are counted
+// once per image and the totals may overstate billed storage.
+type ECRRepositoryDetails struct {
+	ARN                  string          `json:"arn"`
+	URI                  string          `json:"uri"`
+	ImageCount           int             `json:"imageCount"`
+	TaggedImageCount     int             `json:"taggedImageCount"`
+	UntaggedImageCount   int             `json:"untaggedImageCount"`
+	SizeBytes            int64           `json:"sizeBytes"`
+	UntaggedSizeBytes    int64           `json:"untaggedSizeBytes"`
+	HasLifecyclePolicy   bool            `json:"hasLifecyclePolicy"`
+	LifecyclePolicyKnown bool            `json:"lifecyclePolicyKnown"`
+	LifecycleSimulation  ECRPolicyResult `json:"lifecycleSimulation"`
+}
+
+// ECRPolicyResult is the outcome of simulating a lifecycle policy that expires untagged
+// images and keeps only the most recently pushed tagged images
+type ECRPolicyResult struct {
+	KeepTaggedImages        int     `json:"keepTaggedImages"`
+	ExpiredImages           int     `json:"expiredImages"`
+	ExpiredSizeBytes        int64   `json:"expiredSizeBytes"`
+	EstimatedMonthlySavings float64 `json:"estimatedMonthlySavings"`
+}
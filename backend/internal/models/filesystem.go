@@ -0,0 +1,31 @@
+package models
+
+// EFSFileSystemDetails holds the details of an EFS file system
+type EFSFileSystemDetails struct {
+	ARN                          string  `json:"arn"`
+	PerformanceMode              string  `json:"performanceMode"`
+	ThroughputMode               string  `json:"throughputMode"`
+	ProvisionedThroughputInMibps float64 `json:"provisionedThroughputInMibps"`
+	AvailabilityZone             string  `json:"availabilityZone"`
+	MountTargets                 int32   `json:"mountTargets"`
+	SizeBytes                    int64   `json:"sizeBytes"`
+	StandardBytes                int64   `json:"standardBytes"`
+	IABytes                      int64   `json:"iaBytes"`
+	ArchiveBytes                 int64   `json:"archiveBytes"`
+	TransitionToIA               string  `json:"transitionToIa"`
+	TransitionToArchive          string  `json:"transitionToArchive"`
+	LifecycleKnown               bool    `json:"lifecycleKnown"`
+}
+
+// FSxFileSystemDetails holds the details of an FSx file system
+type FSxFileSystemDetails struct {
+	ARN                      string `json:"arn"`
+	FileSystemType           string `json:"fileSystemType"`
+	DeploymentType           string `json:"deploymentType"`
+	StorageType              string `json:"storageType"`
+	StorageCapacityGB        int32  `json:"storageCapacityGb"`
+	ThroughputCapacityMBps   int32  `json:"throughputCapacityMbps"`
+	PerUnitStorageThroughput int32  `json:"perUnitStorageThroughput"`
+	VpcID                    string `json:"vpcId"`
+	PriceKnown               bool   `json:"priceKnown"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// InventorySnapshot is the inventory and its estimated cost as left by one refresh job
+type InventorySnapshot struct {
+	JobID            string              `json:"jobId"`
+	TakenAt          time.Time           `json:"takenAt"`
+	ResourceCount    int                 `json:"resourceCount"`
+	TotalMonthlyCost float64             `json:"totalMonthlyCost"`
+	Collectors       []CollectorSnapshot `json:"collectors"`
+}
+
+// CollectorSnapshot is the part of an inventory snapshot that one collector returned. When the
+// collector failed, its resources were carried forward from the previous inventory.
+type CollectorSnapshot struct {
+	Name           string             `json:"name"`
+	ResourceType   ResourceType       `json:"resourceType"`
+	CarriedForward bool               `json:"carriedForward"`
+	ResourceCount  int                `json:"resourceCount"`
+	MonthlyCost    float64            `json:"monthlyCost"`
+	Resources      []SnapshotResource `json:"resources,omitempty"`
+}
+
+// SnapshotResource is a resource and its monthly cost at the time of a snapshot
+type SnapshotResource struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Type        ResourceType `json:"type"`
+	MonthlyCost float64      `json:"monthlyCost"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// JobStatus is the state of a refresh job or of one collector within it
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobPartial   JobStatus = "partial"
+	JobFailed    JobStatus = "failed"
+)
+
+// CollectorRun reports how one collector fared during a refresh job
+type CollectorRun struct {
+	Name         string       `json:"name"`
+	ResourceType ResourceType `json:"resourceType"`
+	Status       JobStatus    `json:"status"`
+	StartedAt    *time.Time   `json:"startedAt"`
+	FinishedAt   *time.Time   `json:"finishedAt"`
+	DurationMs   int64        `json:"durationMs"`
+	ItemCount    int          `json:"itemCount"`
+	Error        string       `json:"error,omitempty"`
+}
+
+// RefreshJob is a run of every registered collector. A job whose collectors partly failed
+// still replaces the stored resources with what was collected.
+type RefreshJob struct {
+	ID            string         `json:"id"`
+	Trigger       string         `json:"trigger"`
+	Status        JobStatus      `json:"status"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	FinishedAt    *time.Time     `json:"finishedAt"`
+	DurationMs    int64          `json:"durationMs"`
+	ResourceCount int            `json:"resourceCount"`
+	ErrorCount    int            `json:"errorCount"`
+	Collectors    []CollectorRun `json:"collectors"`
+}
+
+// RefreshStarted is the response to a refresh request. Coalesced is set when the job was
+// already running.
+type RefreshStarted struct {
+	JobID     string     `json:"jobId"`
+	Coalesced bool       `json:"coalesced"`
+	Job       RefreshJob `json:"job"`
+}
+
+// CollectorInfo describes a registered collector
+type CollectorInfo struct {
+	Name         string       `json:"name"`
+	ResourceType ResourceType `json:"resourceType"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// LambdaFunctionDetails holds the Lambda-specific details of a function resource
+type LambdaFunctionDetails struct {
+	ARN                    string  `json:"arn"`
+	Runtime                string  `json:"runtime"`
+	PackageType            string  `json:"packageType"`
+	MemorySizeMB           int32   `json:"memorySizeMb"`
+	Architecture           string  `json:"architecture"`
+	TimeoutSeconds         int32   `json:"timeoutSeconds"`
+	MonthlyInvocations     float64 `json:"monthlyInvocations"`
+	AverageDurationMs      float64 `json:"averageDurationMs"`
+	MonthlyGBSeconds       float64 `json:"monthlyGbSeconds"`
+	PeakMemoryUtilization  float64 `json:"peakMemoryUtilization"`
+	MemoryUtilizationKnown bool    `json:"memoryUtilizationKnown"`
+}
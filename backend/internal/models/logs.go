@@ -0,0 +1,10 @@
+package models
+
+// LogGroupDetails holds the details of a CloudWatch Logs log group
+type LogGroupDetails struct {
+	ARN               string `json:"arn"`
+	StoredBytes       int64  `json:"storedBytes"`
+	RetentionDays     int32  `json:"retentionDays"`
+	MetricFilterCount int32  `json:"metricFilterCount"`
+	LogGroupClass     string `json:"logGroupClass"`
+}
@@ -0,0 +1,87 @@
+package models
+
+// LoadBalancerDetails holds the details of an application, network, gateway or classic load balancer
+type LoadBalancerDetails struct {
+	ARN                   string  `json:"arn"`
+	LoadBalancerType      string  `json:"loadBalancerType"`
+	Scheme                string  `json:"scheme"`
+	DNSName               string  `json:"dnsName"`
+	VPCID                 string  `json:"vpcId"`
+	TargetGroupCount      int     `json:"targetGroupCount"`
+	TotalTargets          int     `json:"totalTargets"`
+	HealthyTargets        int     `json:"healthyTargets"`
+	TargetHealthKnown     bool    `json:"targetHealthKnown"`
+	MonthlyCapacityUnits  float64 `json:"monthlyCapacityUnits"`
+	MonthlyProcessedBytes float64 `json:"monthlyProcessedBytes"`
+	HourlyMonthlyCost     float64 `json:"hourlyMonthlyCost"`
+	UsageMonthlyCost      float64 `json:"usageMonthlyCost"`
+}
+
+// NATGatewayDetails holds the details of a NAT gateway
+type NATGatewayDetails struct {
+	VPCID                 string   `json:"vpcId"`
+	SubnetID              string   `json:"subnetId"`
+	ConnectivityType      string   `json:"connectivityType"`
+	PublicIPs             []string `json:"publicIps"`
+	MonthlyProcessedBytes float64  `json:"monthlyProcessedBytes"`
+	TrafficKnown          bool     `json:"trafficKnown"`
+	HourlyMonthlyCost     float64  `json:"hourlyMonthlyCost"`
+	DataMonthlyCost       float64  `json:"dataMonthlyCost"`
+}
+
+// VPCEndpointDetails holds the details of an interface or Gateway Load Balancer VPC endpoint
+type VPCEndpointDetails struct {
+	VPCID             string   `json:"vpcId"`
+	ServiceName       string   `json:"serviceName"`
+	EndpointType      string   `json:"endpointType"`
+	SubnetIDs         []string `json:"subnetIds"`
+	AvailabilityZones []string `json:"availabilityZones"`
+	PrivateDNSEnabled bool     `json:"privateDnsEnabled"`
+	DuplicateOf       []string `json:"duplicateOf"`
+}
+
+// TransitGatewayAttachmentDetails holds the details of a Transit Gateway attachment
+type TransitGatewayAttachmentDetails struct {
+	TransitGatewayID  string   `json:"transitGatewayId"`
+	ResourceType      string   `json:"resourceType"`
+	ResourceID        string   `json:"resourceId"`
+	ResourceOwnerID   string   `json:"resourceOwnerId"`
+	SubnetIDs         []string `json:"subnetIds"`
+	AvailabilityZones []string `json:"availabilityZones"`
+}
+
+// VPNConnectionDetails holds the details of a Site-to-Site VPN connection
+type VPNConnectionDetails struct {
+	VPNGatewayID      string `json:"vpnGatewayId"`
+	TransitGatewayID  string `json:"transitGatewayId"`
+	CustomerGatewayID string `json:"customerGatewayId"`
+	Category          string `json:"category"`
+	TunnelsUp         int    `json:"tunnelsUp"`
+	Tunnels           int    `json:"tunnels"`
+}
+
+// NetworkAZSummary counts the hourly-billed networking resources deployed in an Availability Zone
+type NetworkAZSummary struct {
+	AvailabilityZone          string  `json:"availabilityZone"`
+	VPCEndpoints              int     `json:"vpcEndpoints"`
+	TransitGatewayAttachments int     `json:"transitGatewayAttachments"`
+	MonthlyCost               float64 `json:"monthlyCost"`
+}
+
+// DuplicateEndpointGroup lists interface endpoints for the same service in the same VPC
+type DuplicateEndpointGroup struct {
+	VPCID                   string   `json:"vpcId"`
+	ServiceName             string   `json:"serviceName"`
+	EndpointIDs             []string `json:"endpointIds"`
+	EstimatedMonthlySavings float64  `json:"estimatedMonthlySavings"`
+}
+
+// NetworkCostSummary summarizes VPC endpoint, Transit Gateway and VPN charges
+type NetworkCostSummary struct {
+	ByAvailabilityZone []NetworkAZSummary       `json:"byAvailabilityZone"`
+	DuplicateEndpoints []DuplicateEndpointGroup `json:"duplicateEndpoints"`
+	VPCEndpointCost    float64                  `json:"vpcEndpointCost"`
+	TransitGatewayCost float64                  `json:"transitGatewayCost"`
+	VPNConnectionCost  float64                  `json:"vpnConnectionCost"`
+	TotalMonthlyCost   float64                  `json:"totalMonthlyCost"`
+}
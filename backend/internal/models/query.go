@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// SavedQuery is a named resource query. When Alert is set, every refresh raises an alert for
+// each resource that newly matches it.
+type SavedQuery struct {
+	Name        string    `json:"name"`
+	Query       string    `json:"query"`
+	Description string    `json:"description"`
+	Alert       bool      `json:"alert"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
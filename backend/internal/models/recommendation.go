@@ -0,0 +1,15 @@
+package models
+
+// Recommendation is a flag raised on a resource, listed with the resource's cost and the flag's
+// estimated savings (0 when the check makes no estimate)
+type Recommendation struct {
+	ResourceID     string       `json:"resourceId"`
+	ResourceName   string       `json:"resourceName"`
+	ResourceType   ResourceType `json:"resourceType"`
+	Region         string       `json:"region"`
+	AccountID      string       `json:"accountId"`
+	Code           string       `json:"code"`
+	Message        string       `json:"message"`
+	MonthlyCost    float64      `json:"monthlyCost"`
+	MonthlySavings float64      `json:"monthlySavings"`
+}
@@ -6,9 +6,34 @@ import "time"
 type ResourceType string
 
 const (
-	EC2Instance ResourceType = "EC2Instance"
-	RDSInstance ResourceType = "RDSInstance"
-	S3Bucket    ResourceType = "S3Bucket"
+	EC2Instance              ResourceType = "EC2Instance"
+	RDSInstance              ResourceType = "RDSInstance"
+	S3Bucket                 ResourceType = "S3Bucket"
+	LambdaFunction           ResourceType = "LambdaFunction"
+	LoadBalancer             ResourceType = "LoadBalancer"
+	NATGateway               ResourceType = "NATGateway"
+	DynamoDBTable            ResourceType = "DynamoDBTable"
+	ElastiCacheCluster       ResourceType = "ElastiCacheCluster"
+	OpenSearchDomain         ResourceType = "OpenSearchDomain"
+	RedshiftCluster          ResourceType = "RedshiftCluster"
+	EKSCluster               ResourceType = "EKSCluster"
+	EKSNodeGroup             ResourceType = "EKSNodeGroup"
+	EKSFargateProfile        ResourceType = "EKSFargateProfile"
+	ECSCluster               ResourceType = "ECSCluster"
+	ECSService               ResourceType = "ECSService"
+	EFSFileSystem            ResourceType = "EFSFileSystem"
+	FSxFileSystem            ResourceType = "FSxFileSystem"
+	VPCEndpoint              ResourceType = "VPCEndpoint"
+	TransitGatewayAttachment ResourceType = "TransitGatewayAttachment"
+	VPNConnection            ResourceType = "VPNConnection"
+	ECRRepository            ResourceType = "ECRRepository"
+	SecretsManagerSecret     ResourceType = "SecretsManagerSecret"
+	KMSKey                   ResourceType = "KMSKey"
+	Route53HostedZone        ResourceType = "Route53HostedZone"
+	CloudWatchAlarm          ResourceType = "CloudWatchAlarm"
+	CloudWatchMetrics        ResourceType = "CloudWatchMetrics"
+	EBSVolume                ResourceType = "EBSVolume"
+	CloudWatchLogGroup       ResourceType = "CloudWatchLogGroup"
 	// Add more resource types as needed
 )
 
@@ -24,6 +49,37 @@ type Resource struct {
 	DailyCost   float64      `json:"dailyCost"`
 	MonthlyCost float64      `json:"monthlyCost"`
 	Tags        []Tag        `json:"tags"`
+	Flags       []Flag       `json:"flags"`
+	ParentID    string       `json:"parentId"`
+	AccountID   string       `json:"accountId"`
+	// CostSource is "cur" when the costs come from Cost and Usage Report line items rather than
+	// list-price estimates
+	CostSource string `json:"costSource,omitempty"`
+}
+
+// DetailTypes are the details structs collectors attach to resources
+var DetailTypes = []any{
+	EC2InstanceDetails{},
+	EKSClusterDetails{},
+	EKSNodeGroupDetails{},
+	EKSFargateProfileDetails{},
+	ECSClusterDetails{},
+	ECSServiceDetails{},
+	S3BucketDetails{},
+	LambdaFunctionDetails{},
+	LoadBalancerDetails{},
+	NATGatewayDetails{},
+	DynamoDBTableDetails{},
+	ManagedClusterDetails{},
+	EFSFileSystemDetails{},
+	FSxFileSystemDetails{},
+	VPCEndpointDetails{},
+	TransitGatewayAttachmentDetails{},
+	VPNConnectionDetails{},
+	ECRRepositoryDetails{},
+	UnitFeeDetails{},
+	EBSVolumeDetails{},
+	LogGroupDetails{},
 }
 
 // Tag represents a resource tag
@@ -32,6 +88,14 @@ type Tag struct {
 	Value string `json:"value"`
 }
 
+// Flag marks a resource condition worth reviewing, such as a missing lifecycle policy
+type Flag struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// MonthlySavings is the check's estimate of what acting on the flag saves, when it makes one
+	MonthlySavings float64 `json:"monthlySavings,omitempty"`
+}
+
 // CostSummary provides cost information for all resources
 type CostSummary struct {
 	TotalDailyCost   float64                `json:"totalDailyCost"`
@@ -0,0 +1,10 @@
+package models
+
+// S3BucketDetails holds the S3-specific details of a bucket resource
+type S3BucketDetails struct {
+	VersioningStatus       string           `json:"versioningStatus"`
+	HasLifecyclePolicy     bool             `json:"hasLifecyclePolicy"`
+	SizeBytes              int64            `json:"sizeBytes"`
+	SizeByStorageClass     map[string]int64 `json:"sizeByStorageClass"`
+	NoncurrentVersionBytes int64            `json:"noncurrentVersionBytes"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// UnitFeeDetails holds the details of a resource billed a flat monthly fee per unit,
+// such as a secret, a customer-managed key or the custom metrics of a namespace
+type UnitFeeDetails struct {
+	Service          string            `json:"service"`
+	Unit             string            `json:"unit"`
+	Units            float64           `json:"units"`
+	UnitMonthlyPrice float64           `json:"unitMonthlyPrice"`
+	Attributes       map[string]string `json:"attributes"`
+}
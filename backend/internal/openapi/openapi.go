@@ -0,0 +1,280 @@
+// Package openapi builds OpenAPI 3 documents from route descriptions, deriving the schemas of
+// request and response bodies from the Go models by reflection.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version is the OpenAPI version of the generated documents
+const Version = "3.0.3"
+
+// Document is an OpenAPI document
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+
+	// types maps the Go types already in the components to their schema names
+	types map[reflect.Type]string
+	// base is the prefix routes are registered under, such as /api
+	base string
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server is a base URL of the API
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem maps lower-case HTTP methods to the operations of a path
+type PathItem map[string]*Operation
+
+// Components holds the reusable schemas
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Operation describes one endpoint
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body an operation accepts
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a response of an operation
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]*Header   `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Header describes a response header
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType is the schema of a body in one content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Param describes a query parameter of a route
+type Param struct {
+	Name        string
+	Description string
+	Required    bool
+	// Type is the JSON type of the value; empty means string
+	Type string
+	Enum []string
+}
+
+// Route describes an endpoint for the document. Path is the gin path relative to the document's
+// base, with :name path parameters.
+type Route struct {
+	Method  string
+	Path    string
+	ID      string
+	Summary string
+	Tag     string
+	Query   []Param
+	// Body is a value of the JSON request body type, or nil
+	Body any
+	// BodyContent lists the content types of a non-JSON request body, such as file uploads
+	BodyContent []string
+	// Status is the success status; zero means 200
+	Status int
+	// Response is a value of the JSON response type, or nil for an empty response
+	Response any
+	// Content lists the content types of a non-JSON response, such as downloads and streams
+	Content []string
+	// List marks endpoints that accept the list query and set the list response headers
+	List bool
+}
+
+// New creates an empty document for routes registered under base
+func New(title, version, base string) *Document {
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       Info{Title: title, Version: version},
+		Servers:    []Server{{URL: base}},
+		Paths:      make(map[string]*PathItem),
+		Components: Components{Schemas: make(map[string]*Schema)},
+		types:      make(map[reflect.Type]string),
+		base:       base,
+	}
+	doc.Components.Schemas["Error"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"error":    {Type: "string"},
+			"position": {Type: "integer", Description: "Offset of a query syntax or type error"},
+		},
+		Required: []string{"error"},
+	}
+	return doc
+}
+
+// listParams are the query parameters accepted by list endpoints
+var listParams = []Param{
+	{Name: "q", Description: "Search names, IDs and ARNs"},
+	{Name: "sort", Description: "Comma-separated fields to sort by, prefixed with - for descending order"},
+	{Name: "limit", Type: "integer", Description: "Maximum number of items to return, up to 1000"},
+	{Name: "cursor", Description: "The X-Next-Cursor header of the previous page"},
+}
+
+// listHeaders are the response headers set by list endpoints
+var listHeaders = map[string]*Header{
+	"X-Total-Count":        {Description: "Number of matching items before pagination", Schema: &Schema{Type: "integer"}},
+	"X-Next-Cursor":        {Description: "Cursor of the next page, absent on the last page", Schema: &Schema{Type: "string"}},
+	"X-Total-Monthly-Cost": {Description: "Combined monthly cost of the matching items", Schema: &Schema{Type: "number"}},
+}
+
+// Add adds routes to the document
+func (d *Document) Add(routes ...Route) {
+	for _, route := range routes {
+		path, pathParams := openAPIPath(route.Path)
+		item, ok := d.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			d.Paths[path] = item
+		}
+
+		op := &Operation{
+			OperationID: route.ID,
+			Summary:     route.Summary,
+			Responses:   make(map[string]*Response),
+		}
+		if route.Tag != "" {
+			op.Tags = []string{route.Tag}
+		}
+		for _, name := range pathParams {
+			op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		query := route.Query
+		if route.List {
+			query = append(append([]Param{}, query...), listParams...)
+		}
+		for _, param := range query {
+			schema := &Schema{Type: param.Type, Enum: param.Enum}
+			if schema.Type == "" {
+				schema.Type = "string"
+			}
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:        param.Name,
+				In:          "query",
+				Description: param.Description,
+				Required:    param.Required,
+				Schema:      schema,
+			})
+		}
+
+		if route.Body != nil {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{"application/json": {Schema: d.SchemaOf(route.Body)}},
+			}
+		}
+		if len(route.BodyContent) > 0 {
+			op.RequestBody = &RequestBody{Required: true, Content: make(map[string]MediaType)}
+			for _, contentType := range route.BodyContent {
+				op.RequestBody.Content[contentType] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+			}
+		}
+
+		status := route.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		response := &Response{Description: http.StatusText(status)}
+		if route.Response != nil {
+			response.Content = map[string]MediaType{"application/json": {Schema: d.SchemaOf(route.Response)}}
+		}
+		if len(route.Content) > 0 {
+			response.Content = make(map[string]MediaType)
+			for _, contentType := range route.Content {
+				response.Content[contentType] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+			}
+		}
+		if route.List {
+			response.Headers = listHeaders
+		}
+		op.Responses[fmt.Sprint(status)] = response
+		op.Responses["default"] = &Response{
+			Description: "Error",
+			Content:     map[string]MediaType{"application/json": {Schema: &Schema{Ref: "#/components/schemas/Error"}}},
+		}
+
+		(*item)[strings.ToLower(route.Method)] = op
+	}
+}
+
+// openAPIPath converts a gin path such as /jobs/:id to /jobs/{id} and returns its parameters
+func openAPIPath(path string) (string, []string) {
+	var params []string
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// Undocumented returns the registered routes under the document's base that the document does
+// not describe, as "METHOD /path"
+func (d *Document) Undocumented(routes gin.RoutesInfo) []string {
+	var missing []string
+	for _, route := range routes {
+		rel, ok := strings.CutPrefix(route.Path, d.base)
+		if !ok {
+			continue
+		}
+		path, _ := openAPIPath(rel)
+		if item, ok := d.Paths[path]; !ok || (*item)[strings.ToLower(route.Method)] == nil {
+			missing = append(missing, route.Method+" "+route.Path)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// Handler serves the document as JSON
+func (d *Document) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, d)
+	}
+}
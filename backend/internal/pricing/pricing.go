@@ -0,0 +1,462 @@
+package pricing
+
+// HoursPerMonth is the number of hours AWS uses to convert hourly rates into monthly charges
+const HoursPerMonth = 730
+
+// BytesPerGB is the number of bytes in a billing gigabyte
+const BytesPerGB = 1 << 30
+
+// Prices below are us-east-1 on-demand list prices in USD. They are used for
+// estimates only; Cost Explorer remains the source of truth for billed amounts.
+
+// ebsGBMonth holds the monthly price per provisioned GB for each EBS volume type
+var ebsGBMonth = map[string]float64{
+	"gp2":      0.10,
+	"gp3":      0.08,
+	"io1":      0.125,
+	"io2":      0.125,
+	"st1":      0.045,
+	"sc1":      0.015,
+	"standard": 0.05,
+}
+
+// rdsStorageGBMonth holds the monthly price per allocated GB for each RDS storage type
+var rdsStorageGBMonth = map[string]float64{
+	"gp2":      0.115,
+	"gp3":      0.115,
+	"io1":      0.125,
+	"io2":      0.125,
+	"standard": 0.10,
+}
+
+// publicIPv4Hourly is the hourly charge for every public IPv4 address, attached or not
+const publicIPv4Hourly = 0.005
+
+// EBSVolumeMonthly returns the estimated monthly storage cost of an EBS volume
+func EBSVolumeMonthly(volumeType string, sizeGB int32) float64 {
+	price, ok := ebsGBMonth[volumeType]
+	if !ok {
+		price = ebsGBMonth["gp2"]
+	}
+	return price * float64(sizeGB)
+}
+
+// RDSStorageMonthly returns the estimated monthly cost of RDS allocated storage
+func RDSStorageMonthly(storageType string, sizeGB int32) float64 {
+	price, ok := rdsStorageGBMonth[storageType]
+	if !ok {
+		price = rdsStorageGBMonth["gp2"]
+	}
+	return price * float64(sizeGB)
+}
+
+// PublicIPv4Monthly returns the monthly charge for a single public IPv4 address
+func PublicIPv4Monthly() float64 {
+	return publicIPv4Hourly * HoursPerMonth
+}
+
+// s3GBMonth holds the monthly price per stored GB keyed by the CloudWatch
+// BucketSizeBytes StorageType dimension
+var s3GBMonth = map[string]float64{
+	"StandardStorage":                0.023,
+	"IntelligentTieringFAStorage":    0.023,
+	"IntelligentTieringIAStorage":    0.0125,
+	"IntelligentTieringAAStorage":    0.004,
+	"IntelligentTieringAIAStorage":   0.004,
+	"IntelligentTieringDAAStorage":   0.00099,
+	"StandardIAStorage":              0.0125,
+	"OneZoneIAStorage":               0.01,
+	"ReducedRedundancyStorage":       0.024,
+	"GlacierInstantRetrievalStorage": 0.004,
+	"GlacierStorage":                 0.0036,
+	"DeepArchiveStorage":             0.00099,
+}
+
+// S3StorageMonthly returns the estimated monthly cost of storing bytes in an S3 storage class
+func S3StorageMonthly(storageType string, bytes int64) float64 {
+	price, ok := s3GBMonth[storageType]
+	if !ok {
+		price = s3GBMonth["StandardStorage"]
+	}
+	return price * float64(bytes) / BytesPerGB
+}
+
+// Lambda prices: requests are billed per million, compute per GB-second by architecture
+const lambdaRequestsPerMillion = 0.20
+
+var lambdaGBSecond = map[string]float64{
+	"x86_64": 0.0000166667,
+	"arm64":  0.0000133334,
+}
+
+// LambdaCost returns the cost of a number of invocations consuming the given GB-seconds
+func LambdaCost(architecture string, invocations, gbSeconds float64) float64 {
+	price, ok := lambdaGBSecond[architecture]
+	if !ok {
+		price = lambdaGBSecond["x86_64"]
+	}
+	return invocations/1e6*lambdaRequestsPerMillion + gbSeconds*price
+}
+
+// loadBalancerRates holds the hourly charge and the usage-based rate for each load balancer type.
+// Application, network and gateway load balancers bill usage per capacity-unit hour,
+// classic load balancers bill per GB processed.
+var loadBalancerRates = map[string]struct {
+	Hourly float64
+	Usage  float64
+}{
+	"application": {Hourly: 0.0225, Usage: 0.008},
+	"network":     {Hourly: 0.0225, Usage: 0.006},
+	"gateway":     {Hourly: 0.0125, Usage: 0.004},
+	"classic":     {Hourly: 0.025, Usage: 0.008},
+}
+
+// LoadBalancerHourlyMonthly returns the monthly fixed charge of a load balancer type
+func LoadBalancerHourlyMonthly(lbType string) float64 {
+	return loadBalancerRates[lbType].Hourly * HoursPerMonth
+}
+
+// LoadBalancerUsageMonthly returns the monthly usage charge of a load balancer type, given
+// capacity-unit hours for application/network/gateway load balancers or GB processed for classic ones
+func LoadBalancerUsageMonthly(lbType string, units float64) float64 {
+	return loadBalancerRates[lbType].Usage * units
+}
+
+// NAT gateway prices: an hourly charge plus a per-GB data processing charge
+const (
+	natGatewayHourly = 0.045
+	natGatewayPerGB  = 0.045
+)
+
+// NATGatewayHourlyMonthly returns the monthly fixed charge of a NAT gateway
+func NATGatewayHourlyMonthly() float64 {
+	return natGatewayHourly * HoursPerMonth
+}
+
+// NATGatewayDataMonthly returns the data processing charge for the bytes a NAT gateway processed
+func NATGatewayDataMonthly(bytes float64) float64 {
+	return natGatewayPerGB * bytes / BytesPerGB
+}
+
+// ecrGBMonth is the monthly price per GB of ECR image storage
+const ecrGBMonth = 0.10
+
+// ECRStorageMonthly returns the monthly cost of storing bytes of ECR images
+func ECRStorageMonthly(bytes int64) float64 {
+	return ecrGBMonth * float64(bytes) / BytesPerGB
+}
+
+// logsStorageGBMonth is the monthly price per GB of archived CloudWatch Logs data
+const logsStorageGBMonth = 0.03
+
+// LogStorageMonthly returns the monthly cost of storing bytes of CloudWatch Logs data
+func LogStorageMonthly(bytes int64) float64 {
+	return logsStorageGBMonth * float64(bytes) / BytesPerGB
+}
+
+// unitMonthlyFee holds flat monthly fees for services billed per unit, keyed by service and unit
+var unitMonthlyFee = map[string]float64{
+	"secretsmanager:secret":            0.40,
+	"kms:key":                          1.00,
+	"route53:hosted-zone":              0.50,
+	"cloudwatch:alarm":                 0.10,
+	"cloudwatch:high-resolution-alarm": 0.30,
+	"cloudwatch:composite-alarm":       0.50,
+	"cloudwatch:custom-metric":         0.30,
+}
+
+// UnitMonthlyFee returns the monthly fee of one unit, such as a secret or a customer-managed key
+func UnitMonthlyFee(unit string) float64 {
+	return unitMonthlyFee[unit]
+}
+
+// VPC networking hourly prices
+const (
+	vpcEndpointAZHourly            = 0.01
+	transitGatewayAttachmentHourly = 0.05
+	vpnConnectionHourly            = 0.05
+)
+
+// VPCEndpointMonthly returns the monthly fixed charge of an interface or Gateway Load Balancer
+// endpoint, which is billed for each Availability Zone it is deployed in
+func VPCEndpointMonthly(availabilityZones int) float64 {
+	return vpcEndpointAZHourly * float64(availabilityZones) * HoursPerMonth
+}
+
+// TransitGatewayAttachmentMonthly returns the monthly fixed charge of a Transit Gateway attachment
+func TransitGatewayAttachmentMonthly() float64 {
+	return transitGatewayAttachmentHourly * HoursPerMonth
+}
+
+// VPNConnectionMonthly returns the monthly fixed charge of a Site-to-Site VPN connection
+func VPNConnectionMonthly() float64 {
+	return vpnConnectionHourly * HoursPerMonth
+}
+
+// ebsSnapshotGBMonth holds the monthly price per snapshot GB for each storage tier
+var ebsSnapshotGBMonth = map[string]float64{
+	"standard": 0.05,
+	"archive":  0.0125,
+}
+
+// rdsSnapshotGBMonth is the monthly price per GB of RDS backup storage beyond the free allowance
+const rdsSnapshotGBMonth = 0.095
+
+// EBSSnapshotMonthly returns the estimated monthly storage cost of an EBS snapshot
+func EBSSnapshotMonthly(storageTier string, sizeGB int32) float64 {
+	price, ok := ebsSnapshotGBMonth[storageTier]
+	if !ok {
+		price = ebsSnapshotGBMonth["standard"]
+	}
+	return price * float64(sizeGB)
+}
+
+// RDSSnapshotMonthly returns the estimated monthly storage cost of an RDS snapshot
+func RDSSnapshotMonthly(sizeGB int32) float64 {
+	return rdsSnapshotGBMonth * float64(sizeGB)
+}
+
+// DynamoDB prices for the standard table class
+const (
+	dynamoDBRCUHourly          = 0.00013
+	dynamoDBWCUHourly          = 0.00065
+	dynamoDBReadPerMillion     = 0.125
+	dynamoDBWritePerMillion    = 0.625
+	dynamoDBStorageGBMonth     = 0.25
+	dynamoDBPITRStorageGBMonth = 0.20
+)
+
+// DynamoDBProvisionedMonthly returns the monthly cost of provisioned read and write capacity units
+func DynamoDBProvisionedMonthly(rcu, wcu float64) float64 {
+	return (rcu*dynamoDBRCUHourly + wcu*dynamoDBWCUHourly) * HoursPerMonth
+}
+
+// DynamoDBOnDemandMonthly returns the monthly cost of on-demand read and write request units
+func DynamoDBOnDemandMonthly(readUnits, writeUnits float64) float64 {
+	return readUnits/1e6*dynamoDBReadPerMillion + writeUnits/1e6*dynamoDBWritePerMillion
+}
+
+// DynamoDBStorageMonthly returns the monthly cost of table storage, including
+// continuous backups when point-in-time recovery is enabled
+func DynamoDBStorageMonthly(bytes int64, pitr bool) float64 {
+	gb := float64(bytes) / BytesPerGB
+	cost := gb * dynamoDBStorageGBMonth
+	if pitr {
+		cost += gb * dynamoDBPITRStorageGBMonth
+	}
+	return cost
+}
+
+// nodeHourly holds on-demand hourly node prices for managed cluster services, keyed by service then node type
+var nodeHourly = map[string]map[string]float64{
+	"elasticache": {
+		"cache.t3.micro":    0.017,
+		"cache.t3.small":    0.034,
+		"cache.t3.medium":   0.068,
+		"cache.t4g.micro":   0.016,
+		"cache.t4g.small":   0.032,
+		"cache.t4g.medium":  0.065,
+		"cache.m5.large":    0.156,
+		"cache.m5.xlarge":   0.311,
+		"cache.m5.2xlarge":  0.623,
+		"cache.m6g.large":   0.149,
+		"cache.m6g.xlarge":  0.297,
+		"cache.m6g.2xlarge": 0.594,
+		"cache.m7g.large":   0.158,
+		"cache.m7g.xlarge":  0.315,
+		"cache.r5.large":    0.216,
+		"cache.r5.xlarge":   0.431,
+		"cache.r5.2xlarge":  0.862,
+		"cache.r6g.large":   0.206,
+		"cache.r6g.xlarge":  0.411,
+		"cache.r6g.2xlarge": 0.822,
+		"cache.r7g.large":   0.219,
+		"cache.r7g.xlarge":  0.437,
+	},
+	"opensearch": {
+		"t3.small.search":          0.036,
+		"t3.medium.search":         0.073,
+		"m5.large.search":          0.142,
+		"m5.xlarge.search":         0.283,
+		"m6g.large.search":         0.128,
+		"m6g.xlarge.search":        0.256,
+		"c5.large.search":          0.125,
+		"c6g.large.search":         0.113,
+		"r5.large.search":          0.186,
+		"r5.xlarge.search":         0.372,
+		"r6g.large.search":         0.167,
+		"r6g.xlarge.search":        0.335,
+		"ultrawarm1.medium.search": 0.238,
+		"ultrawarm1.large.search":  2.68,
+	},
+	"redshift": {
+		"dc2.large":    0.25,
+		"dc2.8xlarge":  4.80,
+		"ra3.xlplus":   1.086,
+		"ra3.4xlarge":  3.26,
+		"ra3.16xlarge": 13.04,
+	},
+}
+
+// openSearchStorageGBMonth is the monthly price per GB of OpenSearch EBS storage
+const openSearchStorageGBMonth = 0.122
+
+// NodeHourly returns the hourly price of a managed cluster node and whether the node type is known
+func NodeHourly(service, nodeType string) (float64, bool) {
+	price, ok := nodeHourly[service][nodeType]
+	return price, ok
+}
+
+// OpenSearchStorageMonthly returns the monthly cost of OpenSearch EBS storage
+func OpenSearchStorageMonthly(sizeGB int32) float64 {
+	return openSearchStorageGBMonth * float64(sizeGB)
+}
+
+// ec2Hourly holds Linux on-demand hourly prices for common EC2 instance types
+var ec2Hourly = map[string]float64{
+	"t2.micro":    0.0116,
+	"t2.small":    0.023,
+	"t2.medium":   0.0464,
+	"t2.large":    0.0928,
+	"t3.nano":     0.0052,
+	"t3.micro":    0.0104,
+	"t3.small":    0.0208,
+	"t3.medium":   0.0416,
+	"t3.large":    0.0832,
+	"t3.xlarge":   0.1664,
+	"t3.2xlarge":  0.3328,
+	"t3a.micro":   0.0094,
+	"t3a.small":   0.0188,
+	"t3a.medium":  0.0376,
+	"t3a.large":   0.0752,
+	"t4g.micro":   0.0084,
+	"t4g.small":   0.0168,
+	"t4g.medium":  0.0336,
+	"t4g.large":   0.0672,
+	"m5.large":    0.096,
+	"m5.xlarge":   0.192,
+	"m5.2xlarge":  0.384,
+	"m5.4xlarge":  0.768,
+	"m6i.large":   0.096,
+	"m6i.xlarge":  0.192,
+	"m6i.2xlarge": 0.384,
+	"m6g.large":   0.077,
+	"m6g.xlarge":  0.154,
+	"m7g.large":   0.0816,
+	"m7i.large":   0.1008,
+	"c5.large":    0.085,
+	"c5.xlarge":   0.17,
+	"c5.2xlarge":  0.34,
+	"c6i.large":   0.085,
+	"c6i.xlarge":  0.17,
+	"c6g.large":   0.068,
+	"c6g.xlarge":  0.136,
+	"c7g.large":   0.0725,
+	"r5.large":    0.126,
+	"r5.xlarge":   0.252,
+	"r5.2xlarge":  0.504,
+	"r6i.large":   0.126,
+	"r6g.large":   0.1008,
+	"r6g.xlarge":  0.2016,
+}
+
+// EC2InstanceHourly returns the on-demand hourly price of an instance type and whether it is known
+func EC2InstanceHourly(instanceType string) (float64, bool) {
+	price, ok := ec2Hourly[instanceType]
+	return price, ok
+}
+
+// Container platform prices
+const (
+	eksControlPlaneHourly = 0.10
+	fargateVCPUHourly     = 0.04048
+	fargateGBHourly       = 0.004445
+)
+
+// EKSControlPlaneMonthly returns the monthly fee of an EKS cluster control plane
+func EKSControlPlaneMonthly() float64 {
+	return eksControlPlaneHourly * HoursPerMonth
+}
+
+// FargateTaskMonthly returns the monthly cost of a Fargate task running continuously
+// with the given vCPU and memory (GB)
+func FargateTaskMonthly(vcpu, memoryGB float64) float64 {
+	return (vcpu*fargateVCPUHourly + memoryGB*fargateGBHourly) * HoursPerMonth
+}
+
+// efsGBMonth holds EFS storage prices per GB-month, keyed by storage class. One Zone
+// file systems use the "OneZone" prefixed classes.
+var efsGBMonth = map[string]float64{
+	"Standard":        0.30,
+	"IA":              0.016,
+	"Archive":         0.008,
+	"OneZoneStandard": 0.16,
+	"OneZoneIA":       0.0133,
+}
+
+// efsProvisionedMiBpsMonth is the monthly price per MiB/s of EFS provisioned throughput
+const efsProvisionedMiBpsMonth = 6.00
+
+// EFSStorageMonthly returns the monthly cost of storing bytes in an EFS storage class
+func EFSStorageMonthly(storageClass string, bytes int64) float64 {
+	return efsGBMonth[storageClass] * float64(bytes) / BytesPerGB
+}
+
+// EFSProvisionedThroughputMonthly returns the monthly cost of EFS provisioned throughput
+func EFSProvisionedThroughputMonthly(mibps float64) float64 {
+	return efsProvisionedMiBpsMonth * mibps
+}
+
+// fsxRates holds FSx storage (per GB-month) and throughput (per MBps-month) prices, keyed by
+// file system type, storage type and deployment type
+type fsxRates struct {
+	storageGBMonth    float64
+	throughputMBMonth float64
+}
+
+var fsxPrices = map[string]fsxRates{
+	"WINDOWS/SSD/SINGLE_AZ": {0.13, 2.20},
+	"WINDOWS/SSD/MULTI_AZ":  {0.23, 4.50},
+	"WINDOWS/HDD/SINGLE_AZ": {0.013, 2.20},
+	"WINDOWS/HDD/MULTI_AZ":  {0.025, 4.50},
+	"ONTAP/SSD/SINGLE_AZ":   {0.125, 0.72},
+	"ONTAP/SSD/MULTI_AZ":    {0.25, 1.44},
+	"OPENZFS/SSD/SINGLE_AZ": {0.09, 0.26},
+	"OPENZFS/SSD/MULTI_AZ":  {0.18, 0.52},
+}
+
+// fsxLustreGBMonth holds FSx for Lustre storage prices per GB-month, keyed by per-unit
+// storage throughput in MB/s/TiB. Scratch file systems report no per-unit throughput.
+var fsxLustreGBMonth = map[int32]float64{
+	0:    0.14,
+	12:   0.025,
+	40:   0.083,
+	50:   0.145,
+	100:  0.21,
+	125:  0.145,
+	200:  0.29,
+	250:  0.21,
+	500:  0.34,
+	1000: 0.60,
+}
+
+// FSxMonthly returns the monthly storage and throughput cost of a Windows, ONTAP or OpenZFS
+// file system and whether the configuration is known
+func FSxMonthly(fsType, storageType string, multiAZ bool, storageGB, throughputMBps int32) (float64, bool) {
+	deployment := "SINGLE_AZ"
+	if multiAZ {
+		deployment = "MULTI_AZ"
+	}
+	rates, ok := fsxPrices[fsType+"/"+storageType+"/"+deployment]
+	if !ok {
+		return 0, false
+	}
+	return rates.storageGBMonth*float64(storageGB) + rates.throughputMBMonth*float64(throughputMBps), true
+}
+
+// FSxLustreMonthly returns the monthly cost of an FSx for Lustre file system and whether
+// its throughput tier is known
+func FSxLustreMonthly(perUnitThroughput, storageGB int32) (float64, bool) {
+	price, ok := fsxLustreGBMonth[perUnitThroughput]
+	return price * float64(storageGB), ok
+}
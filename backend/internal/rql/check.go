@@ -0,0 +1,91 @@
+package rql
+
+import "time"
+
+// operators lists the comparison operators each field type supports
+var operators = map[Type]map[string]bool{
+	TypeString:     {"=": true, "!=": true, "~": true, "!~": true},
+	TypeNumber:     {"=": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true},
+	TypeBool:       {"=": true, "!=": true},
+	TypeTime:       {"=": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true},
+	TypeStringList: {"=": true, "!=": true, "~": true, "!~": true},
+	TypeAny:        {"=": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true, "~": true, "!~": true},
+}
+
+// timeLayouts are the accepted formats of time literals
+var timeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+// check resolves every field of expr and verifies that operators and literals fit their types
+func check(expr Expr) error {
+	switch e := expr.(type) {
+	case *LogicalExpr:
+		if err := check(e.Left); err != nil {
+			return err
+		}
+		return check(e.Right)
+	case *NotExpr:
+		return check(e.X)
+	case *Comparison:
+		def, err := resolve(e.Field)
+		if err != nil {
+			return err
+		}
+		e.Field.def = &def
+		if !operators[def.Type][e.Op] {
+			return errorf(e.Field.pos, "operator %s cannot be used with %s field %s", e.Op, def.Type, e.Field.Name)
+		}
+		return checkLiteral(e.Field, &e.Value)
+	case *InExpr:
+		def, err := resolve(e.Field)
+		if err != nil {
+			return err
+		}
+		e.Field.def = &def
+		if def.Type == TypeBool || def.Type == TypeTime {
+			return errorf(e.Field.pos, "IN cannot be used with %s field %s", def.Type, e.Field.Name)
+		}
+		for i := range e.Values {
+			if err := checkLiteral(e.Field, &e.Values[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkLiteral verifies that a literal can be compared with a field
+func checkLiteral(field Field, value *Literal) error {
+	want := field.def.Type
+	switch want {
+	case TypeAny:
+		if value.Type == TypeString {
+			value.time = parseTime(value.Str)
+		}
+		return nil
+	case TypeStringList:
+		want = TypeString
+	case TypeTime:
+		if value.Type != TypeString {
+			return errorf(value.pos, "%s is a time, compare it with a date string such as \"2024-01-31\"", field.Name)
+		}
+		value.time = parseTime(value.Str)
+		if value.time.IsZero() {
+			return errorf(value.pos, "invalid time %q, use YYYY-MM-DD or RFC 3339", value.Str)
+		}
+		return nil
+	}
+	if value.Type != want {
+		return errorf(value.pos, "cannot compare %s field %s with %s %s", want, field.Name, value.Type, value)
+	}
+	return nil
+}
+
+// parseTime parses a time literal, returning the zero time if it is not one
+func parseTime(s string) time.Time {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,195 @@
+package rql
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// Query is a parsed and type-checked query
+type Query struct {
+	src  string
+	expr Expr
+}
+
+// Compile parses and type-checks a query
+func Compile(src string) (*Query, error) {
+	expr, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := check(expr); err != nil {
+		return nil, err
+	}
+	return &Query{src: src, expr: expr}, nil
+}
+
+// String returns the query as written
+func (q *Query) String() string {
+	return q.src
+}
+
+// Expr returns the parsed expression
+func (q *Query) Expr() Expr {
+	return q.expr
+}
+
+// Match reports whether a resource satisfies the query. A comparison with a details field the
+// resource does not have is false.
+func (q *Query) Match(resource models.Resource) bool {
+	return eval(q.expr, &record{Resource: &resource})
+}
+
+// Filter returns the resources that satisfy the query
+func (q *Query) Filter(resources []models.Resource) []models.Resource {
+	matched := make([]models.Resource, 0)
+	for _, resource := range resources {
+		if q.Match(resource) {
+			matched = append(matched, resource)
+		}
+	}
+	return matched
+}
+
+// record is a resource being evaluated, with its details decoded on first use
+type record struct {
+	*models.Resource
+	fields map[string]any
+}
+
+// details returns the resource details as JSON fields
+func (r *record) details() map[string]any {
+	if r.fields == nil {
+		r.fields = map[string]any{}
+		if data, err := json.Marshal(r.Details); err == nil {
+			json.Unmarshal(data, &r.fields)
+		}
+	}
+	return r.fields
+}
+
+func eval(expr Expr, r *record) bool {
+	switch e := expr.(type) {
+	case *LogicalExpr:
+		if e.Op == "AND" {
+			return eval(e.Left, r) && eval(e.Right, r)
+		}
+		return eval(e.Left, r) || eval(e.Right, r)
+	case *NotExpr:
+		return !eval(e.X, r)
+	case *Comparison:
+		value, ok := e.Field.def.get(r)
+		return ok && compareValue(value, e.Op, e.Value)
+	case *InExpr:
+		value, ok := e.Field.def.get(r)
+		if !ok {
+			return false
+		}
+		for _, literal := range e.Values {
+			if compareValue(value, "=", literal) {
+				return !e.Negate
+			}
+		}
+		return e.Negate
+	}
+	return false
+}
+
+// compareValue applies op to a field value and a literal. Lists match when any element does,
+// and != or !~ when none does.
+func compareValue(value any, op string, literal Literal) bool {
+	switch v := value.(type) {
+	case []string:
+		return compareList(len(v), func(i int) any { return v[i] }, op, literal)
+	case []any:
+		return compareList(len(v), func(i int) any { return v[i] }, op, literal)
+	case string:
+		if !literal.time.IsZero() {
+			if t := parseTime(v); !t.IsZero() {
+				return compareOrdered(t.Compare(literal.time), op)
+			}
+		}
+		if literal.Type != TypeString {
+			return false
+		}
+		switch op {
+		case "=":
+			return v == literal.Str
+		case "!=":
+			return v != literal.Str
+		case "~":
+			return strings.Contains(strings.ToLower(v), strings.ToLower(literal.Str))
+		case "!~":
+			return !strings.Contains(strings.ToLower(v), strings.ToLower(literal.Str))
+		}
+		return compareOrdered(strings.Compare(v, literal.Str), op)
+	case float64:
+		if literal.Type != TypeNumber {
+			return false
+		}
+		switch {
+		case v < literal.Num:
+			return compareOrdered(-1, op)
+		case v > literal.Num:
+			return compareOrdered(1, op)
+		}
+		return compareOrdered(0, op)
+	case bool:
+		if literal.Type != TypeBool {
+			return false
+		}
+		switch op {
+		case "=":
+			return v == literal.Bool
+		case "!=":
+			return v != literal.Bool
+		}
+		return false
+	case time.Time:
+		return compareOrdered(v.Compare(literal.time), op)
+	}
+	return false
+}
+
+// compareList matches a list value against a literal
+func compareList(n int, at func(int) any, op string, literal Literal) bool {
+	positive := op
+	switch op {
+	case "!=":
+		positive = "="
+	case "!~":
+		positive = "~"
+	}
+	found := false
+	for i := 0; i < n; i++ {
+		if compareValue(at(i), positive, literal) {
+			found = true
+			break
+		}
+	}
+	if positive != op {
+		return !found
+	}
+	return found
+}
+
+// compareOrdered applies op to the result of a three-way comparison
+func compareOrdered(cmp int, op string) bool {
+	switch op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
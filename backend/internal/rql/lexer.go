@@ -0,0 +1,180 @@
+// Package rql implements the resource query language: boolean expressions over the fields,
+// tags, flags and details of inventoried resources, such as
+//
+//	type = "EFSFileSystem" AND status = "available" AND sizeBytes > 100e9 AND tag.env != "prod"
+package rql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Error is a syntax or type error at a byte offset of the query
+type Error struct {
+	Pos int    `json:"position"`
+	Msg string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+// errorf returns an *Error at pos
+func errorf(pos int, format string, args ...any) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokTrue
+	tokFalse
+	tokAnd
+	tokOr
+	tokNot
+	tokIn
+	tokOp
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int
+}
+
+// keywords are matched case-insensitively
+var keywords = map[string]tokenKind{
+	"and":   tokAnd,
+	"or":    tokOr,
+	"not":   tokNot,
+	"in":    tokIn,
+	"true":  tokTrue,
+	"false": tokFalse,
+}
+
+// lex splits a query into tokens
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		ch := rune(src[i])
+		switch {
+		case unicode.IsSpace(ch):
+			i++
+		case ch == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case ch == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		case ch == '[':
+			tokens = append(tokens, token{kind: tokLBracket, text: "[", pos: i})
+			i++
+		case ch == ']':
+			tokens = append(tokens, token{kind: tokRBracket, text: "]", pos: i})
+			i++
+		case ch == ',':
+			tokens = append(tokens, token{kind: tokComma, text: ",", pos: i})
+			i++
+		case ch == '"' || ch == '\'':
+			end := i + 1
+			for end < len(src) && src[end] != byte(ch) {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(src) {
+				return nil, errorf(i, "unterminated string")
+			}
+			text := src[i : end+1]
+			if ch == '\'' {
+				text = `"` + strings.ReplaceAll(src[i+1:end], `"`, `\"`) + `"`
+			}
+			value, err := strconv.Unquote(text)
+			if err != nil {
+				return nil, errorf(i, "invalid string %s", src[i:end+1])
+			}
+			tokens = append(tokens, token{kind: tokString, text: value, pos: i})
+			i = end + 1
+		case ch == '&' || ch == '|':
+			if i+1 >= len(src) || src[i+1] != byte(ch) {
+				return nil, errorf(i, "unexpected %q, did you mean %c%c?", ch, ch, ch)
+			}
+			kind := tokAnd
+			if ch == '|' {
+				kind = tokOr
+			}
+			tokens = append(tokens, token{kind: kind, text: src[i : i+2], pos: i})
+			i += 2
+		case strings.ContainsRune("=!<>~", ch):
+			op := opText(src, i)
+			switch op {
+			case "!":
+				tokens = append(tokens, token{kind: tokNot, text: op, pos: i})
+			case "==":
+				tokens = append(tokens, token{kind: tokOp, text: "=", pos: i})
+			default:
+				tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+			}
+			i += len(op)
+		case ch == '-' || ch == '.' || unicode.IsDigit(ch):
+			end := i + 1
+			for end < len(src) && (isNumberChar(rune(src[end])) ||
+				((src[end] == '-' || src[end] == '+') && (src[end-1] == 'e' || src[end-1] == 'E'))) {
+				end++
+			}
+			value, err := strconv.ParseFloat(src[i:end], 64)
+			if err != nil {
+				return nil, errorf(i, "invalid number %s", src[i:end])
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[i:end], num: value, pos: i})
+			i = end
+		case unicode.IsLetter(ch) || ch == '_':
+			end := i + 1
+			for end < len(src) && isIdentChar(rune(src[end])) {
+				end++
+			}
+			text := src[i:end]
+			kind, ok := keywords[strings.ToLower(text)]
+			if !ok {
+				kind = tokIdent
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: i})
+			i = end
+		default:
+			return nil, errorf(i, "unexpected character %q", ch)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// opText returns the operator starting at i, as written
+func opText(src string, i int) string {
+	if i+1 < len(src) && (src[i+1] == '=' || (src[i] == '!' && src[i+1] == '~')) {
+		return src[i : i+2]
+	}
+	return src[i : i+1]
+}
+
+// isNumberChar reports whether ch can continue a number literal
+func isNumberChar(ch rune) bool {
+	return unicode.IsDigit(ch) || ch == '.' || ch == 'e' || ch == 'E'
+}
+
+// isIdentChar reports whether ch can continue a field name. Dots separate tag and details
+// paths, and tag keys may contain colons, dashes and slashes.
+func isIdentChar(ch rune) bool {
+	return unicode.IsLetter(ch) || unicode.IsDigit(ch) || strings.ContainsRune("_.:-/", ch)
+}
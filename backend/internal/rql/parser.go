@@ -0,0 +1,262 @@
+package rql
+
+import (
+	"strconv"
+	"time"
+)
+
+// Expr is a node of a parsed query
+type Expr interface {
+	Pos() int
+	String() string
+}
+
+// LogicalExpr combines two expressions with AND or OR
+type LogicalExpr struct {
+	Op    string
+	Left  Expr
+	Right Expr
+}
+
+// NotExpr negates an expression
+type NotExpr struct {
+	X   Expr
+	pos int
+}
+
+// Comparison compares a field with a literal
+type Comparison struct {
+	Field Field
+	Op    string
+	Value Literal
+}
+
+// InExpr tests a field against a list of literals
+type InExpr struct {
+	Field  Field
+	Values []Literal
+	Negate bool
+}
+
+// Field names a resource field, tag (tag.<key>) or details field (details.<name> or <name>)
+type Field struct {
+	Name string
+	pos  int
+	def  *fieldDef
+}
+
+// Literal is a string, number or boolean constant
+type Literal struct {
+	Type Type
+	Str  string
+	Num  float64
+	Bool bool
+	pos  int
+	time time.Time
+}
+
+func (e *LogicalExpr) Pos() int { return e.Left.Pos() }
+func (e *NotExpr) Pos() int     { return e.pos }
+func (e *Comparison) Pos() int  { return e.Field.pos }
+func (e *InExpr) Pos() int      { return e.Field.pos }
+
+func (e *LogicalExpr) String() string {
+	return "(" + e.Left.String() + " " + e.Op + " " + e.Right.String() + ")"
+}
+
+func (e *NotExpr) String() string { return "NOT " + e.X.String() }
+
+func (e *Comparison) String() string {
+	return e.Field.Name + " " + e.Op + " " + e.Value.String()
+}
+
+func (e *InExpr) String() string {
+	s := e.Field.Name
+	if e.Negate {
+		s += " NOT"
+	}
+	s += " IN ["
+	for i, value := range e.Values {
+		if i > 0 {
+			s += ", "
+		}
+		s += value.String()
+	}
+	return s + "]"
+}
+
+func (l Literal) String() string {
+	switch l.Type {
+	case TypeString:
+		return strconv.Quote(l.Str)
+	case TypeNumber:
+		return strconv.FormatFloat(l.Num, 'g', -1, 64)
+	default:
+		return strconv.FormatBool(l.Bool)
+	}
+}
+
+// Parse parses a query without checking its fields or types
+func Parse(src string) (Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, errorf(0, "empty query")
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, errorf(tok.pos, "unexpected %q", tok.text)
+	}
+	return expr, nil
+}
+
+// parser is a recursive descent parser over a token list. Precedence from lowest to highest is
+// OR, AND, NOT, then comparisons and parentheses.
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &LogicalExpr{Op: "OR", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.advance()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &LogicalExpr{Op: "AND", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if tok := p.peek(); tok.kind == tokNot {
+		p.advance()
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &NotExpr{X: x, pos: tok.pos}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != tokRParen {
+			return nil, errorf(closing.pos, "expected \")\"")
+		}
+		return expr, nil
+	case tokIdent:
+		return p.parseComparison(Field{Name: tok.text, pos: tok.pos})
+	case tokEOF:
+		return nil, errorf(tok.pos, "unexpected end of query")
+	default:
+		return nil, errorf(tok.pos, "expected a field name, got %q", tok.text)
+	}
+}
+
+// parseComparison parses what follows a field name. A field on its own, such as priceKnown,
+// is shorthand for "priceKnown = true".
+func (p *parser) parseComparison(field Field) (Expr, error) {
+	tok := p.peek()
+	switch {
+	case tok.kind == tokOp:
+		p.advance()
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return &Comparison{Field: field, Op: tok.text, Value: value}, nil
+	case tok.kind == tokIn:
+		p.advance()
+		return p.parseList(field, false)
+	case tok.kind == tokNot && p.tokens[p.next+1].kind == tokIn:
+		p.advance()
+		p.advance()
+		return p.parseList(field, true)
+	default:
+		return &Comparison{Field: field, Op: "=", Value: Literal{Type: TypeBool, Bool: true, pos: field.pos}}, nil
+	}
+}
+
+func (p *parser) parseList(field Field, negate bool) (Expr, error) {
+	if tok := p.advance(); tok.kind != tokLBracket && tok.kind != tokLParen {
+		return nil, errorf(tok.pos, "expected \"[\" after IN")
+	}
+	expr := &InExpr{Field: field, Negate: negate}
+	for {
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		expr.Values = append(expr.Values, value)
+		tok := p.advance()
+		if tok.kind == tokRBracket || tok.kind == tokRParen {
+			return expr, nil
+		}
+		if tok.kind != tokComma {
+			return nil, errorf(tok.pos, "expected \",\" or \"]\"")
+		}
+	}
+}
+
+func (p *parser) parseLiteral() (Literal, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokString:
+		return Literal{Type: TypeString, Str: tok.text, pos: tok.pos}, nil
+	case tokNumber:
+		return Literal{Type: TypeNumber, Num: tok.num, pos: tok.pos}, nil
+	case tokTrue, tokFalse:
+		return Literal{Type: TypeBool, Bool: tok.kind == tokTrue, pos: tok.pos}, nil
+	case tokEOF:
+		return Literal{}, errorf(tok.pos, "unexpected end of query, expected a value")
+	default:
+		return Literal{}, errorf(tok.pos, "expected a string, number or boolean, got %q", tok.text)
+	}
+}
@@ -0,0 +1,97 @@
+package rql
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+const unattachedQuery = `type = "EBSVolume" AND state = "available" AND size > 100 AND tag.env != "prod"`
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		src, want string
+	}{
+		{unattachedQuery, `(((type = "EBSVolume" AND state = "available") AND size > 100) AND tag.env != "prod")`},
+		{`a = 1 OR b = 2 AND NOT c = true`, `(a = 1 OR (b = 2 AND NOT c = true))`},
+		{`region NOT IN ["us-east-1", "eu-west-1"]`, `region NOT IN ["us-east-1", "eu-west-1"]`},
+	} {
+		expr, err := Parse(test.src)
+		if err != nil {
+			t.Errorf("Parse(%s): %v", test.src, err)
+			continue
+		}
+		if got := expr.String(); got != test.want {
+			t.Errorf("Parse(%s) = %s, want %s", test.src, got, test.want)
+		}
+	}
+
+	for _, src := range []string{``, `type =`, `type = "EBSVolume" AND`, `(type = "a"`, `type "a"`} {
+		if _, err := Parse(src); err == nil {
+			t.Errorf("Parse(%q) succeeded", src)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, src := range []string{
+		unattachedQuery,
+		`details.size >= 100`,
+		`sizeGiB > 100`,
+		`createdAt < "2026-01-01"`,
+	} {
+		if _, err := Compile(src); err != nil {
+			t.Errorf("Compile(%s): %v", src, err)
+		}
+	}
+
+	for _, test := range []struct {
+		src, message string
+	}{
+		{`sizes > 100`, `unknown field "sizes"`},
+		{`size > "large"`, `number`},
+		{`size ~ 100`, `operator ~`},
+		{`monthlyCost = "high"`, `number`},
+	} {
+		_, err := Compile(test.src)
+		var queryErr *Error
+		if !errors.As(err, &queryErr) || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("Compile(%s) error = %v, want one mentioning %s", test.src, err, test.message)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	query, err := Compile(unattachedQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	volume := func(status string, size int32, tags ...models.Tag) models.Resource {
+		return models.Resource{
+			Type:    models.EBSVolume,
+			Status:  status,
+			Tags:    tags,
+			Details: models.EBSVolumeDetails{SizeGiB: size},
+		}
+	}
+
+	for _, test := range []struct {
+		name     string
+		resource models.Resource
+		want     bool
+	}{
+		{"large unattached", volume("available", 500), true},
+		{"large unattached staging", volume("available", 500, models.Tag{Key: "env", Value: "staging"}), true},
+		{"prod", volume("available", 500, models.Tag{Key: "env", Value: "prod"}), false},
+		{"attached", volume("in-use", 500), false},
+		{"small", volume("available", 100), false},
+		{"not a volume", models.Resource{Type: models.EFSFileSystem, Status: "available",
+			Details: models.EFSFileSystemDetails{SizeBytes: 500e9}}, false},
+	} {
+		if got := query.Match(test.resource); got != test.want {
+			t.Errorf("%s: Match = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
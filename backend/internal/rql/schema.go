@@ -0,0 +1,170 @@
+package rql
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// Type is the type of a field or literal
+type Type int
+
+const (
+	TypeString Type = iota
+	TypeNumber
+	TypeBool
+	TypeTime
+	// TypeStringList is a list of strings, matched when any element matches
+	TypeStringList
+	// TypeAny is a details field whose type differs between resource types, checked at run time
+	TypeAny
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeString:
+		return "string"
+	case TypeNumber:
+		return "number"
+	case TypeBool:
+		return "boolean"
+	case TypeTime:
+		return "time"
+	case TypeStringList:
+		return "string list"
+	default:
+		return "any"
+	}
+}
+
+// fieldDef describes a queryable field and reads it from a resource
+type fieldDef struct {
+	Type Type
+	get  func(r *record) (any, bool)
+}
+
+// resourceFields are the top-level resource fields, keyed by lower-case name
+var resourceFields = map[string]fieldDef{
+	"id":          {TypeString, func(r *record) (any, bool) { return r.ID, true }},
+	"name":        {TypeString, func(r *record) (any, bool) { return r.Name, true }},
+	"type":        {TypeString, func(r *record) (any, bool) { return string(r.Type), true }},
+	"region":      {TypeString, func(r *record) (any, bool) { return r.Region, true }},
+	"status":      {TypeString, func(r *record) (any, bool) { return r.Status, true }},
+	"state":       {TypeString, func(r *record) (any, bool) { return r.Status, true }},
+	"accountid":   {TypeString, func(r *record) (any, bool) { return r.AccountID, true }},
+	"parentid":    {TypeString, func(r *record) (any, bool) { return r.ParentID, true }},
+	"createdat":   {TypeTime, func(r *record) (any, bool) { return r.CreatedAt, !r.CreatedAt.IsZero() }},
+	"dailycost":   {TypeNumber, func(r *record) (any, bool) { return r.DailyCost, true }},
+	"monthlycost": {TypeNumber, func(r *record) (any, bool) { return r.MonthlyCost, true }},
+	"costsource":  {TypeString, func(r *record) (any, bool) { return r.CostSource, true }},
+	"flag": {TypeStringList, func(r *record) (any, bool) {
+		codes := make([]string, 0, len(r.Flags))
+		for _, flag := range r.Flags {
+			codes = append(codes, flag.Code)
+		}
+		return codes, true
+	}},
+}
+
+// detailAliases maps lower-case shorthand names to the details fields they stand for
+var detailAliases = map[string]string{
+	"size": "sizegib",
+}
+
+// detailFields maps lower-case details field names to their JSON name and type. Details fields
+// can be queried as details.<field> or, when the name is not a resource field, just <field>.
+var detailFields = buildDetailFields()
+
+type detailField struct {
+	jsonName string
+	typ      Type
+}
+
+// buildDetailFields collects the queryable fields of every details struct. Fields that are
+// structs, maps or lists of non-strings cannot be compared and are left out.
+func buildDetailFields() map[string]detailField {
+	fields := make(map[string]detailField)
+	for _, details := range models.DetailTypes {
+		t := reflect.TypeOf(details)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			typ, ok := typeOf(f.Type)
+			if !ok {
+				continue
+			}
+			key := strings.ToLower(name)
+			if existing, seen := fields[key]; seen && existing.typ != typ {
+				typ = TypeAny
+			}
+			fields[key] = detailField{jsonName: name, typ: typ}
+		}
+	}
+	return fields
+}
+
+// typeOf maps a Go type to a query type
+func typeOf(t reflect.Type) (Type, bool) {
+	if t == reflect.TypeOf(time.Time{}) {
+		return TypeTime, true
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return TypeString, true
+	case reflect.Bool:
+		return TypeBool, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return TypeNumber, true
+	case reflect.Pointer:
+		return typeOf(t.Elem())
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return TypeStringList, true
+		}
+	}
+	return 0, false
+}
+
+// resolve finds the definition of a field name
+func resolve(field Field) (fieldDef, error) {
+	name := field.Name
+	if key, ok := strings.CutPrefix(name, "tag."); ok {
+		if key == "" {
+			return fieldDef{}, errorf(field.pos, "missing tag key after \"tag.\"")
+		}
+		return fieldDef{TypeString, func(r *record) (any, bool) {
+			for _, tag := range r.Tags {
+				if tag.Key == key {
+					return tag.Value, true
+				}
+			}
+			// A missing tag compares as empty, so tag.env != "prod" matches untagged resources
+			return "", true
+		}}, nil
+	}
+
+	lower := strings.ToLower(name)
+	detailsName, qualified := strings.CutPrefix(lower, "details.")
+	if !qualified {
+		if def, ok := resourceFields[lower]; ok {
+			return def, nil
+		}
+	}
+	if alias, ok := detailAliases[detailsName]; ok {
+		detailsName = alias
+	}
+	if detail, ok := detailFields[detailsName]; ok {
+		return fieldDef{detail.typ, func(r *record) (any, bool) {
+			value, ok := r.details()[detail.jsonName]
+			return value, ok && value != nil
+		}}, nil
+	}
+	return fieldDef{}, errorf(field.pos, "unknown field %q", name)
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/events"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// costChangeThreshold is the monthly cost difference in USD below which a resource is not reported as changed
+const costChangeThreshold = 0.01
+
+// Events returns the broker that refresh, alert and resource change events are published to
+func (s *ResourceService) Events() *events.Broker {
+	return s.events
+}
+
+// publishChanges publishes the resources a refresh added, changed or removed, and an alert for
+// every flag a resource did not have before. Resources of the skipped types are not compared.
+func (s *ResourceService) publishChanges(jobID string, previous, current []models.Resource, skipTypes map[models.ResourceType]bool) {
+	before := make(map[string]models.Resource, len(previous))
+	for _, resource := range previous {
+		before[resource.ID] = resource
+	}
+
+	changes := models.ResourceChanges{
+		JobID:   jobID,
+		Added:   make([]models.Resource, 0),
+		Changed: make([]models.Resource, 0),
+		Removed: make([]string, 0),
+	}
+	var alerts []models.Alert
+	now := time.Now()
+	seen := make(map[string]bool, len(current))
+
+	for _, resource := range current {
+		seen[resource.ID] = true
+		if skipTypes[resource.Type] {
+			continue
+		}
+		old, ok := before[resource.ID]
+		if !ok {
+			changes.Added = append(changes.Added, resource)
+		} else if resourceChanged(old, resource) {
+			changes.Changed = append(changes.Changed, resource)
+		}
+
+		oldFlags := make(map[string]bool, len(old.Flags))
+		for _, flag := range old.Flags {
+			oldFlags[flag.Code] = true
+		}
+		for _, flag := range resource.Flags {
+			if !oldFlags[flag.Code] {
+				alerts = append(alerts, models.Alert{
+					ResourceID:   resource.ID,
+					ResourceName: resource.Name,
+					ResourceType: resource.Type,
+					Region:       resource.Region,
+					Code:         flag.Code,
+					Message:      flag.Message,
+					RaisedAt:     now,
+				})
+			}
+		}
+	}
+
+	for _, resource := range previous {
+		if !seen[resource.ID] && !skipTypes[resource.Type] {
+			changes.Removed = append(changes.Removed, resource.ID)
+		}
+	}
+
+	if len(changes.Added)+len(changes.Changed)+len(changes.Removed) > 0 {
+		s.events.Publish(events.ResourcesChanged, changes)
+	}
+	for _, alert := range alerts {
+		s.events.Publish(events.Alert, alert)
+	}
+}
+
+// resourceChanged reports whether the status, cost or flags of a resource changed
+func resourceChanged(old, current models.Resource) bool {
+	if old.Status != current.Status || old.ParentID != current.ParentID {
+		return true
+	}
+	if math.Abs(old.MonthlyCost-current.MonthlyCost) >= costChangeThreshold {
+		return true
+	}
+	if len(old.Flags) != len(current.Flags) {
+		return true
+	}
+	for i := range old.Flags {
+		if old.Flags[i].Code != current.Flags[i].Code {
+			return true
+		}
+	}
+	return false
+}
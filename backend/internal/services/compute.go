@@ -0,0 +1,103 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// EKS tags that managed node groups apply to their instances
+const (
+	eksClusterTag   = "eks:cluster-name"
+	eksNodeGroupTag = "eks:nodegroup-name"
+)
+
+// GetComputeGroups returns EKS and ECS clusters with the cost of everything running in them
+func (s *ResourceService) GetComputeGroups() []models.ComputeGroup {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return buildComputeGroups(s.resources)
+}
+
+// linkComputeParents sets the parent of EC2 instances to the EKS node group or
+// ECS cluster they belong to
+func linkComputeParents(resources []models.Resource) {
+	nodeGroups := make(map[string]string)
+	ecsInstances := make(map[string]string)
+	for _, resource := range resources {
+		switch details := resource.Details.(type) {
+		case models.EKSNodeGroupDetails:
+			nodeGroups[details.ClusterName+"/"+resource.Name] = resource.ID
+		case models.ECSClusterDetails:
+			for _, id := range details.ContainerInstanceIDs {
+				ecsInstances[id] = resource.ID
+			}
+		}
+	}
+
+	for i := range resources {
+		resource := &resources[i]
+		if resource.Type != models.EC2Instance || resource.ParentID != "" {
+			continue
+		}
+
+		cluster, group := "", ""
+		for _, tag := range resource.Tags {
+			switch tag.Key {
+			case eksClusterTag:
+				cluster = tag.Value
+			case eksNodeGroupTag:
+				group = tag.Value
+			}
+		}
+		if id, ok := nodeGroups[cluster+"/"+group]; ok {
+			resource.ParentID = id
+		} else if id, ok := ecsInstances[resource.ID]; ok {
+			resource.ParentID = id
+		}
+	}
+}
+
+// buildComputeGroups builds a cost tree for every EKS and ECS cluster from resource parents
+func buildComputeGroups(resources []models.Resource) []models.ComputeGroup {
+	children := make(map[string][]models.Resource)
+	for _, resource := range resources {
+		if resource.ParentID != "" {
+			children[resource.ParentID] = append(children[resource.ParentID], resource)
+		}
+	}
+
+	groups := make([]models.ComputeGroup, 0)
+	for _, resource := range resources {
+		if resource.Type == models.EKSCluster || resource.Type == models.ECSCluster {
+			groups = append(groups, newComputeGroup(resource, children))
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].TotalMonthlyCost > groups[j].TotalMonthlyCost
+	})
+	return groups
+}
+
+// newComputeGroup builds the group for a resource and rolls up the cost of its children
+func newComputeGroup(resource models.Resource, children map[string][]models.Resource) models.ComputeGroup {
+	group := models.ComputeGroup{
+		ID:               resource.ID,
+		Name:             resource.Name,
+		Type:             resource.Type,
+		MonthlyCost:      resource.MonthlyCost,
+		TotalMonthlyCost: resource.MonthlyCost,
+		ResourceCount:    1,
+		Children:         make([]models.ComputeGroup, 0),
+	}
+
+	for _, child := range children[resource.ID] {
+		childGroup := newComputeGroup(child, children)
+		group.TotalMonthlyCost += childGroup.TotalMonthlyCost
+		group.ResourceCount += childGroup.ResourceCount
+		group.Children = append(group.Children, childGroup)
+	}
+
+	return group
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/cur"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// ErrCURDisabled is returned by CUR operations when no CUR source is configured
+var ErrCURDisabled = errors.New("no Cost and Usage Report source is configured")
+
+// EnableCUR makes refreshes take resource costs from the CUR files of store, and ingests them
+// in the background
+func (s *ResourceService) EnableCUR(store *cur.Store) {
+	s.mu.Lock()
+	s.cur = store
+	s.mu.Unlock()
+
+	go s.SyncCUR(context.Background())
+}
+
+// curStore returns the CUR store, or nil when CUR ingestion is not enabled
+func (s *ResourceService) curStore() *cur.Store {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cur
+}
+
+// SyncCUR ingests new and changed CUR files and applies their costs to the stored resources.
+// Files that fail to ingest are reported in the status and the error.
+func (s *ResourceService) SyncCUR(ctx context.Context) (models.CURStatus, error) {
+	store := s.curStore()
+	if store == nil {
+		return models.CURStatus{}, ErrCURDisabled
+	}
+
+	err := store.Sync(ctx)
+
+	s.mu.Lock()
+	resources := make([]models.Resource, len(s.resources))
+	copy(resources, s.resources)
+	applyCURCosts(store, resources)
+	s.resources = resources
+	s.mu.Unlock()
+
+	return s.GetCURStatus(), err
+}
+
+// GetCURStatus returns the CUR source and the files ingested from it
+func (s *ResourceService) GetCURStatus() models.CURStatus {
+	store := s.curStore()
+	if store == nil {
+		return models.CURStatus{Files: []models.CURFile{}}
+	}
+
+	status := models.CURStatus{Enabled: true, Source: store.Source(), Files: store.Files()}
+	if lastSync := store.LastSync(); !lastSync.IsZero() {
+		status.LastSync = &lastSync
+	}
+	if day, ok := store.LatestDay(); ok {
+		status.LatestDay = &day
+	}
+	return status
+}
+
+// GetResourceCost returns the hourly or daily cost of a resource from CUR line items
+func (s *ResourceService) GetResourceCost(id string, start, end time.Time, granularity string) (models.ResourceCost, error) {
+	store := s.curStore()
+	if store == nil {
+		return models.ResourceCost{}, ErrCURDisabled
+	}
+
+	var bucket time.Duration
+	switch granularity {
+	case "", "hourly":
+		granularity, bucket = "hourly", time.Hour
+	case "daily":
+		bucket = 24 * time.Hour
+	default:
+		return models.ResourceCost{}, fmt.Errorf("granularity must be hourly or daily")
+	}
+
+	points, total, _ := store.Cost(id, start, end, bucket)
+	return models.ResourceCost{
+		ResourceID:  id,
+		Granularity: granularity,
+		Start:       start,
+		End:         end,
+		Total:       total,
+		Currency:    store.Currency(),
+		Points:      points,
+	}, nil
+}
+
+// applyCURCosts sets the daily cost of each resource with CUR line items to its cost on the
+// latest complete day of usage, replacing the list-price estimate
+func applyCURCosts(store *cur.Store, resources []models.Resource) {
+	day, ok := store.LatestDay()
+	if !ok {
+		return
+	}
+	for i := range resources {
+		_, total, found := store.Cost(resources[i].ID, day, day.AddDate(0, 0, 1), 24*time.Hour)
+		if !found {
+			continue
+		}
+		resources[i].DailyCost = total
+		resources[i].MonthlyCost = total * 30
+		resources[i].CostSource = "cur"
+	}
+}
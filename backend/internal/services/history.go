@@ -0,0 +1,97 @@
+package services
+
+import (
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/collector"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// maxInventoryHistory is the number of inventory snapshots kept for /api/history
+const maxInventoryHistory = 50
+
+// GetHistory returns retained inventory snapshots, most recent first, without their resources.
+// A non-empty collector name narrows each snapshot to that collector.
+func (s *ResourceService) GetHistory(collectorName string) []models.InventorySnapshot {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	history := make([]models.InventorySnapshot, 0, len(s.history))
+	for i := len(s.history) - 1; i >= 0; i-- {
+		snapshot := *s.history[i]
+		snapshot.Collectors = make([]models.CollectorSnapshot, 0, len(s.history[i].Collectors))
+		for _, c := range s.history[i].Collectors {
+			if collectorName != "" && c.Name != collectorName {
+				continue
+			}
+			c.Resources = nil
+			snapshot.Collectors = append(snapshot.Collectors, c)
+		}
+		history = append(history, snapshot)
+	}
+	return history
+}
+
+// GetSnapshot returns the inventory snapshot taken by a refresh job, with its resources
+func (s *ResourceService) GetSnapshot(jobID string) (models.InventorySnapshot, bool) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	for _, snapshot := range s.history {
+		if snapshot.JobID == jobID {
+			c := *snapshot
+			c.Collectors = append([]models.CollectorSnapshot(nil), snapshot.Collectors...)
+			return c, true
+		}
+	}
+	return models.InventorySnapshot{}, false
+}
+
+// recordSnapshot adds the inventory left by a job to the history. Each resource is attributed
+// to the collector that returned its type, and failed collectors are marked as carried forward.
+func (s *ResourceService) recordSnapshot(job *models.RefreshJob, collectors []collector.Collector, resources []models.Resource) {
+	snapshot := &models.InventorySnapshot{
+		JobID:      job.ID,
+		TakenAt:    time.Now(),
+		Collectors: make([]models.CollectorSnapshot, 0, len(collectors)),
+	}
+
+	owners := make(map[models.ResourceType]int)
+	for i, c := range collectors {
+		snapshot.Collectors = append(snapshot.Collectors, models.CollectorSnapshot{
+			Name:           c.Name(),
+			ResourceType:   c.ResourceType(),
+			CarriedForward: job.Collectors[i].Status == models.JobFailed,
+		})
+		owners[c.ResourceType()] = i
+		for _, resourceType := range s.collectorTypes[c.Name()] {
+			owners[resourceType] = i
+		}
+	}
+
+	for _, resource := range resources {
+		snapshot.ResourceCount++
+		snapshot.TotalMonthlyCost += resource.MonthlyCost
+
+		i, ok := owners[resource.Type]
+		if !ok {
+			continue
+		}
+		c := &snapshot.Collectors[i]
+		c.ResourceCount++
+		c.MonthlyCost += resource.MonthlyCost
+		c.Resources = append(c.Resources, models.SnapshotResource{
+			ID:          resource.ID,
+			Name:        resource.Name,
+			Type:        resource.Type,
+			MonthlyCost: resource.MonthlyCost,
+		})
+	}
+
+	s.updateJob(func() {
+		s.history = append(s.history, snapshot)
+		if len(s.history) > maxInventoryHistory {
+			s.history = s.history[len(s.history)-maxInventoryHistory:]
+		}
+	})
+}
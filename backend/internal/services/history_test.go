@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+func TestHistoryRecordsEachRefreshPerCollector(t *testing.T) {
+	stubs := map[string]*stubCollector{
+		"ec2": {resources: []models.Resource{
+			{ID: "i-1", Type: models.EC2Instance, MonthlyCost: 30},
+			{ID: "i-2", Type: models.EC2Instance, MonthlyCost: 10},
+		}},
+		"eks": {resources: []models.Resource{
+			{ID: "cluster", Type: models.EKSCluster, MonthlyCost: 73},
+			{ID: "cluster/workers", Type: models.EKSNodeGroup, ParentID: "cluster"},
+		}},
+	}
+	service := newTestService(t, stubs, map[string]models.ResourceType{"ec2": models.EC2Instance, "eks": models.EKSCluster})
+
+	first, _ := refresh(t, service)
+	stubs["ec2"].resources = stubs["ec2"].resources[:1]
+	stubs["eks"].failing = true
+	second, _ := refresh(t, service)
+
+	history := service.GetHistory("")
+	if len(history) != 2 || history[0].JobID != second.ID || history[1].JobID != first.ID {
+		t.Fatalf("history = %+v, want the second refresh then the first", history)
+	}
+	for _, test := range []struct {
+		snapshot       models.InventorySnapshot
+		count          int
+		cost           float64
+		ec2Count       int
+		eksCount       int
+		carriedForward bool
+	}{
+		{history[1], 4, 113, 2, 2, false},
+		{history[0], 3, 103, 1, 2, true},
+	} {
+		s := test.snapshot
+		if s.ResourceCount != test.count || s.TotalMonthlyCost != test.cost {
+			t.Errorf("%s: %d resources costing %.2f, want %d costing %.2f",
+				s.JobID, s.ResourceCount, s.TotalMonthlyCost, test.count, test.cost)
+		}
+		if len(s.Collectors) != 2 {
+			t.Fatalf("%s: collectors = %+v", s.JobID, s.Collectors)
+		}
+		ec2, eks := s.Collectors[0], s.Collectors[1]
+		if ec2.ResourceCount != test.ec2Count || eks.ResourceCount != test.eksCount {
+			t.Errorf("%s: ec2 has %d resources and eks %d, want %d and %d",
+				s.JobID, ec2.ResourceCount, eks.ResourceCount, test.ec2Count, test.eksCount)
+		}
+		if eks.CarriedForward != test.carriedForward || ec2.CarriedForward {
+			t.Errorf("%s: carried forward ec2 = %v, eks = %v", s.JobID, ec2.CarriedForward, eks.CarriedForward)
+		}
+		if ec2.Resources != nil {
+			t.Errorf("%s: listed snapshot includes resources", s.JobID)
+		}
+	}
+
+	if narrowed := service.GetHistory("eks"); len(narrowed[0].Collectors) != 1 || narrowed[0].Collectors[0].Name != "eks" {
+		t.Errorf("history of eks = %+v", narrowed[0].Collectors)
+	}
+
+	snapshot, ok := service.GetSnapshot(second.ID)
+	if !ok {
+		t.Fatal("snapshot of the second refresh not found")
+	}
+	if resources := snapshot.Collectors[0].Resources; len(resources) != 1 || resources[0].ID != "i-1" || resources[0].MonthlyCost != 30 {
+		t.Errorf("ec2 resources = %+v", resources)
+	}
+	if _, ok := service.GetSnapshot("missing"); ok {
+		t.Error("snapshot of an unknown job found")
+	}
+}
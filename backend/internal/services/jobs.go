@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/events"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// maxJobHistory is the number of finished refresh jobs kept for /api/jobs
+const maxJobHistory = 50
+
+// Refresh job triggers
+const (
+	TriggerStartup  = "startup"
+	TriggerPeriodic = "periodic"
+	TriggerAPI      = "api"
+)
+
+// StartRefresh starts a refresh job in the background and returns it. When a refresh is already
+// running no new job is started; the running job is returned and coalesced is true.
+func (s *ResourceService) StartRefresh(trigger string) (job models.RefreshJob, coalesced bool) {
+	running, coalesced := s.startRefresh(trigger)
+
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	return copyJob(running), coalesced
+}
+
+// GetJob returns a refresh job by ID
+func (s *ResourceService) GetJob(id string) (models.RefreshJob, bool) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	for _, job := range s.jobs {
+		if job.ID == id {
+			return copyJob(job), true
+		}
+	}
+	return models.RefreshJob{}, false
+}
+
+// GetJobs returns retained refresh jobs, most recent first
+func (s *ResourceService) GetJobs() []models.RefreshJob {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	jobs := make([]models.RefreshJob, 0, len(s.jobs))
+	for i := len(s.jobs) - 1; i >= 0; i-- {
+		jobs = append(jobs, copyJob(s.jobs[i]))
+	}
+	return jobs
+}
+
+// startRefresh returns the running refresh job, or creates and starts a new one
+func (s *ResourceService) startRefresh(trigger string) (*models.RefreshJob, bool) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	if s.running != nil {
+		return s.running, true
+	}
+
+	job := &models.RefreshJob{
+		ID:         newJobID(),
+		Trigger:    trigger,
+		Status:     models.JobRunning,
+		CreatedAt:  time.Now(),
+		Collectors: make([]models.CollectorRun, 0),
+	}
+	collectors := s.registry.Collectors()
+	for _, c := range collectors {
+		job.Collectors = append(job.Collectors, models.CollectorRun{
+			Name:         c.Name(),
+			ResourceType: c.ResourceType(),
+			Status:       models.JobPending,
+		})
+	}
+
+	s.jobs = append(s.jobs, job)
+	if len(s.jobs) > maxJobHistory {
+		s.jobs = s.jobs[len(s.jobs)-maxJobHistory:]
+	}
+
+	s.running = job
+	s.events.Publish(events.RefreshStarted, copyJob(job))
+
+	// The job outlives the request that started it
+	go s.runRefresh(context.Background(), job, collectors)
+
+	return job, false
+}
+
+// updateJob applies a change to a job under the jobs lock
+func (s *ResourceService) updateJob(update func()) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	update()
+}
+
+// finishCollectorRun records the outcome of a collector in its job
+func (s *ResourceService) finishCollectorRun(job *models.RefreshJob, run *models.CollectorRun, started time.Time, count int, err error) {
+	s.updateJob(func() {
+		finished := time.Now()
+		run.FinishedAt = &finished
+		run.DurationMs = finished.Sub(started).Milliseconds()
+		run.ItemCount = count
+		run.Status = models.JobSucceeded
+		if err != nil {
+			run.Status = models.JobFailed
+			run.Error = err.Error()
+		}
+		s.recordCollectorRun(run.Name, finished.Sub(started), err != nil)
+
+		progress := models.RefreshProgress{JobID: job.ID, Collector: *run, Total: len(job.Collectors)}
+		for _, other := range job.Collectors {
+			if other.FinishedAt != nil {
+				progress.Completed++
+			}
+		}
+		s.events.Publish(events.RefreshProgress, progress)
+	})
+}
+
+// finishJob records the overall outcome of a job. The job stops being the running one before
+// its completion is published, so a refresh started on that event is not coalesced into it.
+func (s *ResourceService) finishJob(job *models.RefreshJob, resourceCount int) {
+	s.updateJob(func() {
+		finished := time.Now()
+		job.FinishedAt = &finished
+		job.DurationMs = finished.Sub(job.CreatedAt).Milliseconds()
+		job.ResourceCount = resourceCount
+
+		job.ErrorCount = 0
+		for _, run := range job.Collectors {
+			if run.Status == models.JobFailed {
+				job.ErrorCount++
+			}
+		}
+
+		switch {
+		case job.ErrorCount == 0:
+			job.Status = models.JobSucceeded
+		case job.ErrorCount < len(job.Collectors):
+			job.Status = models.JobPartial
+		default:
+			job.Status = models.JobFailed
+		}
+
+		if s.running == job {
+			s.running = nil
+		}
+		s.events.Publish(events.RefreshCompleted, copyJob(job))
+	})
+}
+
+// copyJob returns a copy of a job that is safe to use after the jobs lock is released
+func copyJob(job *models.RefreshJob) models.RefreshJob {
+	c := *job
+	c.Collectors = append([]models.CollectorRun(nil), job.Collectors...)
+	return c
+}
+
+// newJobID returns a random job ID
+func newJobID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
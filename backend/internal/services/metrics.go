@@ -0,0 +1,107 @@
+package services
+
+import (
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/focus"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/metrics"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// collectorStats accumulates the runs of a collector since the server started
+type collectorStats struct {
+	runs         int
+	errors       int
+	duration     time.Duration
+	lastDuration time.Duration
+}
+
+// recordCollectorRun adds a finished collector run to its stats. The caller holds the jobs lock.
+func (s *ResourceService) recordCollectorRun(name string, duration time.Duration, failed bool) {
+	stats, ok := s.collectorStats[name]
+	if !ok {
+		stats = &collectorStats{}
+		s.collectorStats[name] = stats
+	}
+	stats.runs++
+	if failed {
+		stats.errors++
+	}
+	stats.duration += duration
+	stats.lastDuration = duration
+}
+
+// Metrics returns the metric families for /metrics. Inventory and cost gauges describe the
+// resources of the latest refresh, so scraping never calls AWS.
+func (s *ResourceService) Metrics() []metrics.Family {
+	cost := metrics.Family{Name: "aws_cost_daily_usd", Type: metrics.Gauge,
+		Help: "Estimated daily cost in USD of the inventoried resources, by billing service, account and region"}
+	resourceCount := metrics.Family{Name: "aws_resources_total", Type: metrics.Gauge,
+		Help: "Number of inventoried resources by type, state and region"}
+	unattached := metrics.Family{Name: "aws_ebs_unattached_bytes", Type: metrics.Gauge,
+		Help: "Provisioned bytes of EBS volumes not attached to any instance"}
+	logBytes := metrics.Family{Name: "aws_logs_stored_bytes", Type: metrics.Gauge,
+		Help: "Bytes stored by each CloudWatch Logs log group, by account and region"}
+	lastRefresh := metrics.Family{Name: "aws_last_refresh_timestamp_seconds", Type: metrics.Gauge,
+		Help: "Unix time the latest refresh finished, or 0 before the first refresh"}
+
+	type costKey struct{ service, account, region string }
+	type countKey struct{ resourceType, state, region string }
+	costs := make(map[costKey]float64)
+	counts := make(map[countKey]int)
+	var unattachedBytes int64
+
+	s.mu.RLock()
+	for _, resource := range s.resources {
+		costs[costKey{focus.ServiceName(resource.Type), resource.AccountID, resource.Region}] += resource.DailyCost
+		counts[countKey{string(resource.Type), resource.Status, resource.Region}]++
+
+		switch details := resource.Details.(type) {
+		case models.EBSVolumeDetails:
+			if details.AttachedTo == "" {
+				unattachedBytes += details.SizeBytes
+			}
+		case models.LogGroupDetails:
+			logBytes.Add(float64(details.StoredBytes), "group", resource.Name, "account", resource.AccountID, "region", resource.Region)
+		}
+	}
+	if !s.lastUpdatedTime.IsZero() {
+		lastRefresh.Add(float64(s.lastUpdatedTime.Unix()))
+	} else {
+		lastRefresh.Add(0)
+	}
+	s.mu.RUnlock()
+
+	for key, value := range costs {
+		cost.Add(value, "service", key.service, "account", key.account, "region", key.region)
+	}
+	for key, count := range counts {
+		resourceCount.Add(float64(count), "type", key.resourceType, "state", key.state, "region", key.region)
+	}
+	unattached.Add(float64(unattachedBytes))
+
+	families := []metrics.Family{cost, resourceCount, unattached, logBytes, lastRefresh}
+	return append(families, s.collectorMetrics()...)
+}
+
+// collectorMetrics returns the run, error and duration metrics of every collector that has run
+func (s *ResourceService) collectorMetrics() []metrics.Family {
+	runs := metrics.Family{Name: "aws_collector_runs_total", Type: metrics.Counter,
+		Help: "Number of collector runs since the server started"}
+	errors := metrics.Family{Name: "aws_collector_errors_total", Type: metrics.Counter,
+		Help: "Number of collector runs that returned an error since the server started"}
+	duration := metrics.Family{Name: "aws_collector_duration_seconds_total", Type: metrics.Counter,
+		Help: "Total time spent in collector runs since the server started"}
+	lastDuration := metrics.Family{Name: "aws_collector_last_duration_seconds", Type: metrics.Gauge,
+		Help: "Duration of the latest run of each collector"}
+
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	for name, stats := range s.collectorStats {
+		runs.Add(float64(stats.runs), "collector", name)
+		errors.Add(float64(stats.errors), "collector", name)
+		duration.Add(stats.duration.Seconds(), "collector", name)
+		lastDuration.Add(stats.lastDuration.Seconds(), "collector", name)
+	}
+	return []metrics.Family{runs, errors, duration, lastDuration}
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/metrics"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+func TestLogGroupMetricsKeepGroupsOfEachRegionApart(t *testing.T) {
+	service := NewOfflineResourceService("123456789012", "us-east-1")
+	for _, region := range []string{"us-east-1", "eu-west-1"} {
+		service.resources = append(service.resources, models.Resource{
+			ID:        "arn:aws:logs:" + region + ":123456789012:log-group:/aws/lambda/api",
+			Name:      "/aws/lambda/api",
+			Type:      models.CloudWatchLogGroup,
+			Region:    region,
+			AccountID: "123456789012",
+			Details:   models.LogGroupDetails{StoredBytes: 1024},
+		})
+	}
+
+	var out bytes.Buffer
+	if err := metrics.Write(&out, service.Metrics()); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`aws_logs_stored_bytes{account="123456789012",group="/aws/lambda/api",region="us-east-1"} 1024`,
+		`aws_logs_stored_bytes{account="123456789012",group="/aws/lambda/api",region="eu-west-1"} 1024`,
+	} {
+		if !strings.Contains(out.String(), want+"\n") {
+			t.Errorf("metrics do not contain %s:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// GetNetworkSummary returns VPC endpoint, Transit Gateway and VPN charges broken down by
+// Availability Zone, with duplicate interface endpoints
+func (s *ResourceService) GetNetworkSummary() models.NetworkCostSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return buildNetworkSummary(s.resources)
+}
+
+// buildNetworkSummary aggregates networking resources. The hourly charge of a Transit Gateway
+// attachment is split evenly across the zones it has subnets in.
+func buildNetworkSummary(resources []models.Resource) models.NetworkCostSummary {
+	summary := models.NetworkCostSummary{
+		ByAvailabilityZone: make([]models.NetworkAZSummary, 0),
+		DuplicateEndpoints: make([]models.DuplicateEndpointGroup, 0),
+	}
+
+	zones := make(map[string]*models.NetworkAZSummary)
+	zone := func(name string) *models.NetworkAZSummary {
+		if zones[name] == nil {
+			zones[name] = &models.NetworkAZSummary{AvailabilityZone: name}
+		}
+		return zones[name]
+	}
+	duplicates := make(map[string]*models.DuplicateEndpointGroup)
+
+	for _, resource := range resources {
+		switch details := resource.Details.(type) {
+		case models.VPCEndpointDetails:
+			summary.VPCEndpointCost += resource.MonthlyCost
+			for _, name := range details.AvailabilityZones {
+				z := zone(name)
+				z.VPCEndpoints++
+				z.MonthlyCost += resource.MonthlyCost / float64(len(details.AvailabilityZones))
+			}
+
+			if len(details.DuplicateOf) > 0 {
+				key := resource.Region + "/" + details.VPCID + "/" + details.ServiceName
+				group, ok := duplicates[key]
+				if !ok {
+					group = &models.DuplicateEndpointGroup{VPCID: details.VPCID, ServiceName: details.ServiceName}
+					duplicates[key] = group
+				} else {
+					// Every endpoint beyond the first could be removed
+					group.EstimatedMonthlySavings += resource.MonthlyCost
+				}
+				group.EndpointIDs = append(group.EndpointIDs, resource.ID)
+			}
+		case models.TransitGatewayAttachmentDetails:
+			summary.TransitGatewayCost += resource.MonthlyCost
+			for _, name := range details.AvailabilityZones {
+				z := zone(name)
+				z.TransitGatewayAttachments++
+				z.MonthlyCost += resource.MonthlyCost / float64(len(details.AvailabilityZones))
+			}
+		case models.VPNConnectionDetails:
+			summary.VPNConnectionCost += resource.MonthlyCost
+		}
+	}
+
+	for _, z := range zones {
+		summary.ByAvailabilityZone = append(summary.ByAvailabilityZone, *z)
+	}
+	sort.Slice(summary.ByAvailabilityZone, func(i, j int) bool {
+		return summary.ByAvailabilityZone[i].AvailabilityZone < summary.ByAvailabilityZone[j].AvailabilityZone
+	})
+
+	for _, group := range duplicates {
+		summary.DuplicateEndpoints = append(summary.DuplicateEndpoints, *group)
+	}
+	sort.Slice(summary.DuplicateEndpoints, func(i, j int) bool {
+		return summary.DuplicateEndpoints[i].EstimatedMonthlySavings > summary.DuplicateEndpoints[j].EstimatedMonthlySavings
+	})
+
+	summary.TotalMonthlyCost = summary.VPCEndpointCost + summary.TransitGatewayCost + summary.VPNConnectionCost
+	return summary
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/events"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/rql"
+)
+
+// savedQuery is a saved query with its compiled form
+type savedQuery struct {
+	models.SavedQuery
+	compiled *rql.Query
+}
+
+// RunQuery returns the resources that match a query
+func (s *ResourceService) RunQuery(src string) ([]models.Resource, error) {
+	query, err := rql.Compile(src)
+	if err != nil {
+		return nil, err
+	}
+	return query.Filter(s.GetAllResources()), nil
+}
+
+// LoadSavedQueries reads saved queries from path and persists later changes there. A missing
+// file is not an error.
+func (s *ResourceService) LoadSavedQueries(path string) error {
+	s.queriesMu.Lock()
+	defer s.queriesMu.Unlock()
+	s.queriesFile = path
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		log.Printf("Error reading saved queries: %v", err)
+		return err
+	}
+
+	var queries []models.SavedQuery
+	if err := json.Unmarshal(data, &queries); err != nil {
+		log.Printf("Error parsing saved queries: %v", err)
+		return err
+	}
+	for _, query := range queries {
+		compiled, err := rql.Compile(query.Query)
+		if err != nil {
+			log.Printf("Error compiling saved query %s: %v", query.Name, err)
+			continue
+		}
+		s.queries[query.Name] = &savedQuery{SavedQuery: query, compiled: compiled}
+	}
+	return nil
+}
+
+// GetSavedQueries returns the saved queries sorted by name
+func (s *ResourceService) GetSavedQueries() []models.SavedQuery {
+	s.queriesMu.Lock()
+	defer s.queriesMu.Unlock()
+	return s.savedQueriesLocked()
+}
+
+// GetSavedQuery returns a saved query by name
+func (s *ResourceService) GetSavedQuery(name string) (models.SavedQuery, bool) {
+	s.queriesMu.Lock()
+	defer s.queriesMu.Unlock()
+	query, ok := s.queries[name]
+	if !ok {
+		return models.SavedQuery{}, false
+	}
+	return query.SavedQuery, true
+}
+
+// SaveQuery creates or replaces a saved query after checking that it compiles
+func (s *ResourceService) SaveQuery(query models.SavedQuery) (models.SavedQuery, error) {
+	query.Name = strings.TrimSpace(query.Name)
+	if query.Name == "" {
+		return query, fmt.Errorf("query name is required")
+	}
+	compiled, err := rql.Compile(query.Query)
+	if err != nil {
+		return query, err
+	}
+
+	s.queriesMu.Lock()
+	defer s.queriesMu.Unlock()
+
+	now := time.Now()
+	query.CreatedAt, query.UpdatedAt = now, now
+	if existing, ok := s.queries[query.Name]; ok {
+		query.CreatedAt = existing.CreatedAt
+	}
+	s.queries[query.Name] = &savedQuery{SavedQuery: query, compiled: compiled}
+	s.persistQueriesLocked()
+	return query, nil
+}
+
+// DeleteSavedQuery removes a saved query, reporting whether it existed
+func (s *ResourceService) DeleteSavedQuery(name string) bool {
+	s.queriesMu.Lock()
+	defer s.queriesMu.Unlock()
+	if _, ok := s.queries[name]; !ok {
+		return false
+	}
+	delete(s.queries, name)
+	s.persistQueriesLocked()
+	return true
+}
+
+// RunSavedQuery returns the resources that match a saved query
+func (s *ResourceService) RunSavedQuery(name string) ([]models.Resource, bool) {
+	s.queriesMu.Lock()
+	query, ok := s.queries[name]
+	s.queriesMu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	return query.compiled.Filter(s.GetAllResources()), true
+}
+
+// publishQueryAlerts raises an alert for every resource that matches an alerting saved query
+// after a refresh but did not before it. Resources of the skipped types are not compared.
+func (s *ResourceService) publishQueryAlerts(previous, current []models.Resource, skipTypes map[models.ResourceType]bool) {
+	s.queriesMu.Lock()
+	var alerting []*savedQuery
+	for _, query := range s.queries {
+		if query.Alert {
+			alerting = append(alerting, query)
+		}
+	}
+	s.queriesMu.Unlock()
+	if len(alerting) == 0 {
+		return
+	}
+
+	before := make(map[string]models.Resource, len(previous))
+	for _, resource := range previous {
+		before[resource.ID] = resource
+	}
+
+	now := time.Now()
+	for _, query := range alerting {
+		message := query.Description
+		if message == "" {
+			message = fmt.Sprintf("Resource matches saved query %s: %s", query.Name, query.Query)
+		}
+		for _, resource := range current {
+			if skipTypes[resource.Type] || !query.compiled.Match(resource) {
+				continue
+			}
+			if old, ok := before[resource.ID]; ok && query.compiled.Match(old) {
+				continue
+			}
+			s.events.Publish(events.Alert, models.Alert{
+				ResourceID:   resource.ID,
+				ResourceName: resource.Name,
+				ResourceType: resource.Type,
+				Region:       resource.Region,
+				Code:         "query:" + query.Name,
+				Message:      message,
+				RaisedAt:     now,
+			})
+		}
+	}
+}
+
+// savedQueriesLocked returns the saved queries sorted by name. s.queriesMu must be held.
+func (s *ResourceService) savedQueriesLocked() []models.SavedQuery {
+	queries := make([]models.SavedQuery, 0, len(s.queries))
+	for _, query := range s.queries {
+		queries = append(queries, query.SavedQuery)
+	}
+	sort.Slice(queries, func(i, j int) bool { return queries[i].Name < queries[j].Name })
+	return queries
+}
+
+// persistQueriesLocked writes the saved queries to the queries file, if one is configured.
+// s.queriesMu must be held.
+func (s *ResourceService) persistQueriesLocked() {
+	if s.queriesFile == "" {
+		return
+	}
+	data, err := json.MarshalIndent(s.savedQueriesLocked(), "", "  ")
+	if err != nil {
+		log.Printf("Error encoding saved queries: %v", err)
+		return
+	}
+	if err := os.WriteFile(s.queriesFile, data, 0o644); err != nil {
+		log.Printf("Error writing saved queries: %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package services
+
+import "github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+
+// GetRecommendations returns one recommendation for every flag raised on a resource
+func (s *ResourceService) GetRecommendations() []models.Recommendation {
+	return BuildRecommendations(s.GetAllResources())
+}
+
+// BuildRecommendations lists the flags of resources with the resource they were raised on
+func BuildRecommendations(resources []models.Resource) []models.Recommendation {
+	recommendations := make([]models.Recommendation, 0)
+	for _, resource := range resources {
+		for _, flag := range resource.Flags {
+			recommendations = append(recommendations, models.Recommendation{
+				ResourceID:     resource.ID,
+				ResourceName:   resource.Name,
+				ResourceType:   resource.Type,
+				Region:         resource.Region,
+				AccountID:      resource.AccountID,
+				Code:           flag.Code,
+				Message:        flag.Message,
+				MonthlyCost:    resource.MonthlyCost,
+				MonthlySavings: flag.MonthlySavings,
+			})
+		}
+	}
+	return recommendations
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+func TestBuildRecommendationsKeepsFlagSavings(t *testing.T) {
+	recommendations := BuildRecommendations([]models.Resource{{
+		ID:          "repo",
+		Type:        models.ECRRepository,
+		MonthlyCost: 40,
+		Flags: []models.Flag{
+			{Code: "add-lifecycle-policy", MonthlySavings: 12.5},
+			{Code: "untagged"},
+		},
+	}})
+
+	if len(recommendations) != 2 {
+		t.Fatalf("got %d recommendations, want 2", len(recommendations))
+	}
+	for i, want := range []float64{12.5, 0} {
+		if got := recommendations[i]; got.MonthlyCost != 40 || got.MonthlySavings != want {
+			t.Errorf("%s: cost %v, savings %v; want 40 and %v", got.Code, got.MonthlyCost, got.MonthlySavings, want)
+		}
+	}
+}
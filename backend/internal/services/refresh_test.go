@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/collector"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/events"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
+)
+
+// stubCollector returns fixed resources, or an error while failing is set
+type stubCollector struct {
+	resources []models.Resource
+	failing   bool
+}
+
+func (c *stubCollector) collect(context.Context, string, string) ([]models.Resource, error) {
+	if c.failing {
+		return nil, errors.New("throttled")
+	}
+	return c.resources, nil
+}
+
+// newTestService returns a service running the given collectors, keyed by name
+func newTestService(t *testing.T, stubs map[string]*stubCollector, types map[string]models.ResourceType) *ResourceService {
+	t.Helper()
+	service := NewOfflineResourceService("123456789012", "us-east-1")
+
+	names := make([]string, 0, len(stubs))
+	for name := range stubs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		service.registry.MustRegister(collector.New(name, types[name], stubs[name].collect))
+	}
+	return service
+}
+
+// refresh runs a refresh to completion and returns its job and the events it published
+func refresh(t *testing.T, service *ResourceService) (models.RefreshJob, []events.Event) {
+	t.Helper()
+	ch, unsubscribe := service.Events().Subscribe(0)
+	defer unsubscribe()
+
+	job, _ := service.StartRefresh(TriggerAPI)
+	var published []events.Event
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-ch:
+			published = append(published, event)
+			if event.Type == events.RefreshCompleted {
+				finished, _ := service.GetJob(job.ID)
+				return finished, published
+			}
+		case <-timeout:
+			t.Fatal("refresh did not complete")
+		}
+	}
+}
+
+// resourceIDs returns the sorted IDs of the service's resources
+func resourceIDs(service *ResourceService) []string {
+	var ids []string
+	for _, resource := range service.GetAllResources() {
+		ids = append(ids, resource.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestRefreshCarriesForwardFailedCollectors(t *testing.T) {
+	stubs := map[string]*stubCollector{
+		"ec2": {resources: []models.Resource{
+			{ID: "i-1", Type: models.EC2Instance},
+			{ID: "i-2", Type: models.EC2Instance},
+		}},
+		"eks": {resources: []models.Resource{
+			{ID: "cluster", Type: models.EKSCluster},
+			{ID: "cluster/workers", Type: models.EKSNodeGroup, ParentID: "cluster"},
+		}},
+	}
+	service := newTestService(t, stubs, map[string]models.ResourceType{"ec2": models.EC2Instance, "eks": models.EKSCluster})
+	all := []string{"cluster", "cluster/workers", "i-1", "i-2"}
+
+	if job, _ := refresh(t, service); job.Status != models.JobSucceeded {
+		t.Fatalf("first refresh status = %s", job.Status)
+	}
+	if ids := resourceIDs(service); !slices.Equal(ids, all) {
+		t.Fatalf("after first refresh resources = %v", ids)
+	}
+
+	// The node groups the EKS collector returned are carried forward with its clusters
+	stubs["eks"].failing = true
+	job, _ := refresh(t, service)
+	if job.Status != models.JobPartial {
+		t.Errorf("partial refresh status = %s", job.Status)
+	}
+	if ids := resourceIDs(service); !slices.Equal(ids, all) {
+		t.Errorf("after partial refresh resources = %v, want %v", ids, all)
+	}
+
+	stubs["ec2"].failing = true
+	job, _ = refresh(t, service)
+	if job.Status != models.JobFailed {
+		t.Errorf("failed refresh status = %s", job.Status)
+	}
+	if ids := resourceIDs(service); !slices.Equal(ids, all) {
+		t.Errorf("after failed refresh resources = %v, want %v", ids, all)
+	}
+
+	// Resources a collector no longer returns are removed once it succeeds again
+	stubs["ec2"].failing = false
+	stubs["ec2"].resources = stubs["ec2"].resources[:1]
+	refresh(t, service)
+	if ids, want := resourceIDs(service), []string{"cluster", "cluster/workers", "i-1"}; !slices.Equal(ids, want) {
+		t.Errorf("after recovery resources = %v, want %v", ids, want)
+	}
+}
+
+// changeSummary lists the added and removed resources and the alerted resource/code pairs of
+// published events
+func changeSummary(published []events.Event) (added, removed, alerts []string) {
+	for _, event := range published {
+		switch data := event.Data.(type) {
+		case models.ResourceChanges:
+			for _, resource := range data.Added {
+				added = append(added, resource.ID)
+			}
+			removed = append(removed, data.Removed...)
+		case models.Alert:
+			alerts = append(alerts, data.ResourceID+" "+data.Code)
+		}
+	}
+	sort.Strings(alerts)
+	return added, removed, alerts
+}
+
+func TestRefreshSkipsChangesOfFailedCollectors(t *testing.T) {
+	unattached := []models.Flag{{Code: "unattached-volume"}}
+	stubs := map[string]*stubCollector{
+		"ec2": {resources: []models.Resource{{ID: "i-1", Type: models.EC2Instance}}},
+		"ebs": {failing: true},
+	}
+	service := newTestService(t, stubs, map[string]models.ResourceType{"ec2": models.EC2Instance, "ebs": models.EBSVolume})
+	if _, err := service.SaveQuery(models.SavedQuery{Name: "unattached", Query: `type = "EBSVolume" AND state = "available"`, Alert: true}); err != nil {
+		t.Fatal(err)
+	}
+	refresh(t, service)
+
+	// The first successful run of a collector is its baseline, like the first refresh
+	stubs["ebs"].failing = false
+	stubs["ebs"].resources = []models.Resource{{ID: "vol-1", Type: models.EBSVolume, Status: "available", Flags: unattached}}
+	_, published := refresh(t, service)
+	if added, removed, alerts := changeSummary(published); len(added)+len(removed)+len(alerts) > 0 {
+		t.Errorf("baseline run published added %v, removed %v, alerts %v", added, removed, alerts)
+	}
+
+	// A transient failure neither removes the volumes nor alerts on them again afterwards
+	stubs["ebs"].failing = true
+	_, published = refresh(t, service)
+	if added, removed, alerts := changeSummary(published); len(added)+len(removed)+len(alerts) > 0 {
+		t.Errorf("failed run published added %v, removed %v, alerts %v", added, removed, alerts)
+	}
+
+	stubs["ebs"].failing = false
+	stubs["ebs"].resources = append(stubs["ebs"].resources,
+		models.Resource{ID: "vol-2", Type: models.EBSVolume, Status: "available", Flags: unattached})
+	_, published = refresh(t, service)
+	added, removed, alerts := changeSummary(published)
+	if !slices.Equal(added, []string{"vol-2"}) || len(removed) > 0 {
+		t.Errorf("recovered run added %v, removed %v; want only vol-2 added", added, removed)
+	}
+	if want := []string{"vol-2 query:unattached", "vol-2 unattached-volume"}; !slices.Equal(alerts, want) {
+		t.Errorf("recovered run alerts = %v, want %v", alerts, want)
+	}
+}
+
+func TestRefreshStartedOnCompletionIsNotCoalesced(t *testing.T) {
+	service := newTestService(t, map[string]*stubCollector{"ec2": {}},
+		map[string]models.ResourceType{"ec2": models.EC2Instance})
+
+	first, _ := refresh(t, service)
+	second, coalesced := service.StartRefresh(TriggerAPI)
+	if coalesced || second.ID == first.ID {
+		t.Errorf("refresh started after %s completed was coalesced into %s", first.ID, second.ID)
+	}
+}
@@ -3,38 +3,77 @@ package services
 import (
 	"context"
 	"log"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/devesh-kumar/aws-resources-cost-board/internal/aws"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/collector"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/cur"
+	"github.com/devesh-kumar/aws-resources-cost-board/internal/events"
 	"github.com/devesh-kumar/aws-resources-cost-board/internal/models"
 )
 
 // ResourceService handles AWS resource operations
 type ResourceService struct {
 	awsClient       *aws.Client
+	registry        *collector.Registry
+	account         string
 	resources       []models.Resource
 	costSummary     models.CostSummary
 	mu              sync.RWMutex
 	lastUpdatedTime time.Time
+	jobsMu          sync.Mutex
+	jobs            []*models.RefreshJob
+	running         *models.RefreshJob
+	history         []*models.InventorySnapshot
+	collectorStats  map[string]*collectorStats
+	// collectorTypes holds the resource types each collector returned on its last success. Only
+	// the running refresh uses it.
+	collectorTypes map[string][]models.ResourceType
+	events         *events.Broker
+	queriesMu      sync.Mutex
+	queries        map[string]*savedQuery
+	queriesFile    string
+	cur            *cur.Store
 }
 
 // NewResourceService creates a new resource service
 func NewResourceService(awsClient *aws.Client) *ResourceService {
-	service := &ResourceService{
-		awsClient: awsClient,
-		resources: []models.Resource{},
-		costSummary: models.CostSummary{
-			ByServiceCost: make(map[string]models.ServiceCost),
-		},
-	}
+	service := newResourceService(awsClient)
+	service.registry.MustRegister(aws.NewClientPool(awsClient).Collectors()...)
 
 	// Initialize with data
-	go service.RefreshData(context.Background())
+	service.StartRefresh(TriggerStartup)
 
 	return service
 }
 
+// NewOfflineResourceService creates a resource service for an account and region that runs only
+// the collectors registered on it and makes no AWS calls of its own. Nothing is collected until
+// a refresh is started. It serves stub inventories, such as in API tests.
+func NewOfflineResourceService(account, region string) *ResourceService {
+	service := newResourceService(&aws.Client{Region: region})
+	service.account = account
+	return service
+}
+
+// newResourceService creates a resource service without collectors and without starting a refresh
+func newResourceService(awsClient *aws.Client) *ResourceService {
+	return &ResourceService{
+		awsClient:      awsClient,
+		registry:       collector.NewRegistry(),
+		events:         events.NewBroker(),
+		queries:        make(map[string]*savedQuery),
+		collectorStats: make(map[string]*collectorStats),
+		collectorTypes: make(map[string][]models.ResourceType),
+		resources:      []models.Resource{},
+		costSummary: models.CostSummary{
+			ByServiceCost: make(map[string]models.ServiceCost),
+		},
+	}
+}
+
 // GetAllResources returns all AWS resources
 func (s *ResourceService) GetAllResources() []models.Resource {
 	s.mu.RLock()
@@ -42,6 +81,11 @@ func (s *ResourceService) GetAllResources() []models.Resource {
 	return s.resources
 }
 
+// Registry returns the collector registry. Collectors registered here are run on every refresh.
+func (s *ResourceService) Registry() *collector.Registry {
+	return s.registry
+}
+
 // GetCostSummary returns cost summary
 func (s *ResourceService) GetCostSummary() models.CostSummary {
 	s.mu.RLock()
@@ -49,27 +93,77 @@ func (s *ResourceService) GetCostSummary() models.CostSummary {
 	return s.costSummary
 }
 
-// RefreshData refreshes all resource data
-func (s *ResourceService) RefreshData(ctx context.Context) error {
-	log.Println("Refreshing AWS resource data...")
+// runRefresh runs every registered collector, recording progress in job, and replaces the
+// stored resources with the result
+func (s *ResourceService) runRefresh(ctx context.Context, job *models.RefreshJob, collectors []collector.Collector) {
+	log.Printf("Refreshing AWS resource data (job %s)...", job.ID)
 
 	// Create new slices to hold the refreshed data
 	var newResources []models.Resource
 
-	// Fetch EC2 instances
-	ec2Resources, err := s.fetchEC2Resources(ctx)
-	if err != nil {
-		log.Printf("Error fetching EC2 resources: %v", err)
-	} else {
-		newResources = append(newResources, ec2Resources...)
+	// Run every registered collector; a failing collector is logged and skipped, and the types it
+	// collects are carried forward from the previous inventory below
+	account := s.accountID(ctx)
+	failedTypes := make(map[models.ResourceType]bool)
+	// baselineTypes are collected for the first time, so there is nothing to compare them with
+	baselineTypes := make(map[models.ResourceType]bool)
+	failures := 0
+	for i, c := range collectors {
+		run := &job.Collectors[i]
+		started := time.Now()
+		s.updateJob(func() {
+			run.Status = models.JobRunning
+			run.StartedAt = &started
+		})
+
+		resources, err := c.Collect(ctx, account, s.awsClient.Region)
+		s.finishCollectorRun(job, run, started, len(resources), err)
+		if err != nil {
+			log.Printf("Error fetching %s resources: %v", c.Name(), err)
+			failures++
+			failedTypes[c.ResourceType()] = true
+			for _, resourceType := range s.collectorTypes[c.Name()] {
+				failedTypes[resourceType] = true
+			}
+			continue
+		}
+		types := collectedTypes(c.ResourceType(), resources)
+		if _, ok := s.collectorTypes[c.Name()]; !ok {
+			for _, resourceType := range types {
+				baselineTypes[resourceType] = true
+			}
+		}
+		s.collectorTypes[c.Name()] = types
+		for i := range resources {
+			if resources[i].AccountID == "" {
+				resources[i].AccountID = account
+			}
+		}
+		newResources = append(newResources, resources...)
 	}
 
-	// Fetch RDS instances
-	rdsResources, err := s.fetchRDSResources(ctx)
-	if err != nil {
-		log.Printf("Error fetching RDS resources: %v", err)
-	} else {
-		newResources = append(newResources, rdsResources...)
+	s.mu.RLock()
+	previous, initialized := s.resources, !s.lastUpdatedTime.IsZero()
+	s.mu.RUnlock()
+
+	// When every collector failed the refresh says nothing about the account, so the previous
+	// inventory is kept as it is
+	if len(collectors) > 0 && failures == len(collectors) {
+		log.Printf("Data refresh failed: all %d collectors returned errors, keeping %d resources", failures, len(previous))
+		s.recordSnapshot(job, collectors, previous)
+		s.finishJob(job, len(previous))
+		return
+	}
+	newResources = carryForward(newResources, previous, failedTypes)
+
+	// Attach instances to the node groups and clusters that run them
+	linkComputeParents(newResources)
+
+	// Replace estimated costs with CUR costs where the report has line items for a resource.
+	// Sync errors are logged and recorded per file, and the files that did ingest still apply.
+	if store := s.curStore(); store != nil {
+		store.Sync(ctx)
+		applyCURCosts(store, newResources)
 	}
 
 	// Calculate costs
@@ -85,22 +179,73 @@ func (s *ResourceService) RefreshData(ctx context.Context) error {
 	s.lastUpdatedTime = time.Now()
 	s.mu.Unlock()
 
+	// Every resource is new after the first refresh, so changes are only reported from the second.
+	// Types whose collector failed were carried forward and are not diffed, and neither are types
+	// a collector returned for the first time.
+	if initialized {
+		skipTypes := failedTypes
+		for resourceType := range baselineTypes {
+			skipTypes[resourceType] = true
+		}
+		s.publishChanges(job.ID, previous, newResources, skipTypes)
+		s.publishQueryAlerts(previous, newResources, skipTypes)
+	}
+
+	s.recordSnapshot(job, collectors, newResources)
+	s.finishJob(job, len(newResources))
 	log.Printf("Data refresh completed. Found %d resources.", len(newResources))
-	return nil
 }
 
-// fetchEC2Resources fetches EC2 instances
-func (s *ResourceService) fetchEC2Resources(ctx context.Context) ([]models.Resource, error) {
-	// Implementation would fetch EC2 instances using the AWS SDK
-	// This is a simplified version
-	return []models.Resource{}, nil
+// collectedTypes returns the resource types a collector returned along with the type it declares,
+// such as the node groups and Fargate profiles of the EKS collector
+func collectedTypes(declared models.ResourceType, resources []models.Resource) []models.ResourceType {
+	types := []models.ResourceType{declared}
+	for _, resource := range resources {
+		if !slices.Contains(types, resource.Type) {
+			types = append(types, resource.Type)
+		}
+	}
+	return types
 }
 
-// fetchRDSResources fetches RDS instances
-func (s *ResourceService) fetchRDSResources(ctx context.Context) ([]models.Resource, error) {
-	// Implementation would fetch RDS instances using the AWS SDK
-	// This is a simplified version
-	return []models.Resource{}, nil
+// carryForward adds the previous resources of the failed types to the refreshed ones, so a
+// collector that is throttled or denied does not empty its part of the inventory until the next
+// refresh. Resources a successful collector returned again are not duplicated.
+func carryForward(current, previous []models.Resource, failedTypes map[models.ResourceType]bool) []models.Resource {
+	if len(failedTypes) == 0 {
+		return current
+	}
+	seen := make(map[string]bool, len(current))
+	for _, resource := range current {
+		seen[resource.ID] = true
+	}
+	for _, resource := range previous {
+		if failedTypes[resource.Type] && !seen[resource.ID] {
+			current = append(current, resource)
+		}
+	}
+	return current
+}
+
+// accountID returns the ID of the account being inventoried, looking it up on first use
+func (s *ResourceService) accountID(ctx context.Context) string {
+	s.mu.RLock()
+	account := s.account
+	s.mu.RUnlock()
+	if account != "" {
+		return account
+	}
+
+	account, err := s.awsClient.AccountID(ctx)
+	if err != nil {
+		log.Printf("Error getting account ID: %v", err)
+		return ""
+	}
+
+	s.mu.Lock()
+	s.account = account
+	s.mu.Unlock()
+	return account
 }
 
 // calculateCosts calculates costs for resources
@@ -0,0 +1,191 @@
+package tabular
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// parquetBatch is the number of rows read from a Parquet file at a time
+const parquetBatch = 256
+
+// parquetColumn describes how a leaf column of a Parquet file becomes a named value
+type parquetColumn struct {
+	name  string
+	logic *format.LogicalType
+	// mapKey and mapValue mark the key and value leaves of a MAP column such as resource_tags
+	mapKey   bool
+	mapValue bool
+}
+
+// parquetReader reads the rows of a Parquet file. Nested columns other than maps of scalars are
+// skipped.
+type parquetReader struct {
+	reader  *parquet.Reader
+	leaves  []parquetColumn
+	columns []string
+	buffer  []parquet.Row
+	next    int
+	count   int
+}
+
+// NewParquetReader reads a Parquet file
+func NewParquetReader(r io.ReaderAt, size int64) (Reader, error) {
+	file, err := parquet.OpenFile(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("opening Parquet file: %w", err)
+	}
+
+	p := &parquetReader{
+		reader: parquet.NewReader(file),
+		buffer: make([]parquet.Row, parquetBatch),
+	}
+	seen := make(map[string]bool)
+	for _, path := range file.Schema().Columns() {
+		leaf, _ := file.Schema().Lookup(path...)
+		column := parquetColumn{name: path[0], logic: leaf.Node.Type().LogicalType()}
+		switch {
+		case len(path) == 1:
+		case len(path) == 3 && path[2] == "key":
+			column.mapKey = true
+		case len(path) == 3 && path[2] == "value":
+			column.mapValue = true
+		default:
+			column.name = ""
+		}
+		p.leaves = append(p.leaves, column)
+		if column.name != "" && !seen[column.name] {
+			seen[column.name] = true
+			p.columns = append(p.columns, column.name)
+		}
+	}
+	return p, nil
+}
+
+func (p *parquetReader) Columns() []string {
+	return p.columns
+}
+
+func (p *parquetReader) Next() (Row, error) {
+	if p.next >= p.count {
+		n, err := p.reader.ReadRows(p.buffer)
+		if n == 0 {
+			if err == nil {
+				err = io.EOF
+			}
+			return nil, err
+		}
+		p.next, p.count = 0, n
+	}
+	values := p.buffer[p.next]
+	p.next++
+
+	row := make(Row, len(p.columns))
+	keys := make(map[string][]string)
+	entries := make(map[string][]string)
+	for _, value := range values {
+		column := p.leaves[value.Column()]
+		if column.name == "" || value.IsNull() {
+			continue
+		}
+		text := formatValue(value, column.logic)
+		switch {
+		case column.mapKey:
+			keys[column.name] = append(keys[column.name], text)
+		case column.mapValue:
+			entries[column.name] = append(entries[column.name], text)
+		default:
+			row[column.name] = text
+		}
+	}
+	for name, names := range keys {
+		object := make(map[string]string, len(names))
+		for i, key := range names {
+			if i < len(entries[name]) {
+				object[key] = entries[name][i]
+			}
+		}
+		data, _ := json.Marshal(object)
+		row[name] = string(data)
+	}
+	return row, nil
+}
+
+func (p *parquetReader) Close() error {
+	return p.reader.Close()
+}
+
+// formatValue renders a Parquet value as text according to its logical type
+func formatValue(value parquet.Value, logic *format.LogicalType) string {
+	switch value.Kind() {
+	case parquet.Boolean:
+		return strconv.FormatBool(value.Boolean())
+	case parquet.Float:
+		return strconv.FormatFloat(float64(value.Float()), 'f', -1, 32)
+	case parquet.Double:
+		return strconv.FormatFloat(value.Double(), 'f', -1, 64)
+	case parquet.Int96:
+		// Legacy timestamps: nanoseconds of the day followed by the Julian day
+		i := value.Int96()
+		nanos := int64(i[1])<<32 | int64(i[0])
+		days := int64(i[2]) - 2440588
+		return time.Unix(days*86400, nanos).UTC().Format(time.RFC3339Nano)
+	case parquet.Int32, parquet.Int64:
+		n := value.Int64()
+		if value.Kind() == parquet.Int32 {
+			n = int64(value.Int32())
+		}
+		if logic != nil {
+			switch {
+			case logic.Timestamp != nil:
+				return timestamp(n, logic.Timestamp.Unit).Format(time.RFC3339Nano)
+			case logic.Date != nil:
+				return time.Unix(n*86400, 0).UTC().Format("2006-01-02")
+			case logic.Decimal != nil:
+				return decimal(big.NewInt(n), logic.Decimal.Scale)
+			}
+		}
+		return strconv.FormatInt(n, 10)
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		if logic != nil && logic.Decimal != nil {
+			return decimal(signedInt(value.ByteArray()), logic.Decimal.Scale)
+		}
+		return string(value.ByteArray())
+	}
+	return value.String()
+}
+
+// timestamp converts an integer timestamp in the given unit
+func timestamp(n int64, unit format.TimeUnit) time.Time {
+	switch {
+	case unit.Millis != nil:
+		return time.UnixMilli(n).UTC()
+	case unit.Micros != nil:
+		return time.UnixMicro(n).UTC()
+	}
+	return time.Unix(0, n).UTC()
+}
+
+// signedInt decodes a big-endian two's complement integer
+func signedInt(b []byte) *big.Int {
+	n := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	return n
+}
+
+// decimal renders an unscaled decimal value
+func decimal(unscaled *big.Int, scale int32) string {
+	value, _ := new(big.Float).SetInt(unscaled).Float64()
+	value /= math.Pow10(int(scale))
+	return strings.TrimSuffix(strconv.FormatFloat(value, 'f', -1, 64), ".0")
+}
@@ -0,0 +1,160 @@
+// Package tabular reads rows of named values from CSV, gzip-compressed CSV and Parquet files,
+// so billing exports in any of those formats can be loaded the same way.
+package tabular
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Row maps column names to values. Parquet values are rendered as text: timestamps as RFC 3339,
+// and map columns such as CUR 2.0 resource_tags as JSON objects.
+type Row map[string]string
+
+// Reader yields rows until it returns io.EOF
+type Reader interface {
+	Columns() []string
+	Next() (Row, error)
+	Close() error
+}
+
+// Format is a tabular file format
+type Format string
+
+const (
+	CSV     Format = "csv"
+	CSVGzip Format = "csv.gz"
+	Parquet Format = "parquet"
+)
+
+// FormatOf infers the format of a file from its name
+func FormatOf(name string) (Format, error) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".csv.gz") || strings.HasSuffix(lower, ".csv.gzip"):
+		return CSVGzip, nil
+	case strings.HasSuffix(lower, ".csv"):
+		return CSV, nil
+	case strings.HasSuffix(lower, ".parquet"):
+		return Parquet, nil
+	}
+	return "", fmt.Errorf("unsupported file %s: expected .csv, .csv.gz or .parquet", name)
+}
+
+// Open opens a file for reading, inferring its format from its name
+func Open(path string) (Reader, error) {
+	format, err := FormatOf(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader Reader
+	switch format {
+	case Parquet:
+		info, statErr := file.Stat()
+		if statErr != nil {
+			file.Close()
+			return nil, statErr
+		}
+		reader, err = NewParquetReader(file, info.Size())
+	case CSVGzip:
+		reader, err = NewCSVReader(file, true)
+	default:
+		reader, err = NewCSVReader(file, false)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return closer{Reader: reader, file: file}, nil
+}
+
+// Read reads data already in memory, such as an upload, in the given format
+func Read(data []byte, format Format) (Reader, error) {
+	switch format {
+	case Parquet:
+		return NewParquetReader(bytes.NewReader(data), int64(len(data)))
+	case CSVGzip:
+		return NewCSVReader(bytes.NewReader(data), true)
+	case CSV:
+		return NewCSVReader(bytes.NewReader(data), false)
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// closer closes the underlying file along with the reader
+type closer struct {
+	Reader
+	file *os.File
+}
+
+func (c closer) Close() error {
+	c.Reader.Close()
+	return c.file.Close()
+}
+
+// csvReader reads rows of a CSV file with a header line
+type csvReader struct {
+	reader  *csv.Reader
+	gzip    *gzip.Reader
+	columns []string
+}
+
+// NewCSVReader reads a CSV stream with a header line, decompressing it first when gzipped is set
+func NewCSVReader(r io.Reader, gzipped bool) (Reader, error) {
+	c := &csvReader{}
+	if gzipped {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		c.gzip, r = gz, gz
+	}
+	c.reader = csv.NewReader(r)
+	c.reader.FieldsPerRecord = -1
+	c.reader.ReuseRecord = true
+
+	header, err := c.reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	for _, column := range header {
+		// Excel and some exporters start the file with a byte order mark
+		c.columns = append(c.columns, strings.TrimPrefix(strings.TrimSpace(column), "\ufeff"))
+	}
+	return c, nil
+}
+
+func (c *csvReader) Columns() []string {
+	return c.columns
+}
+
+func (c *csvReader) Next() (Row, error) {
+	record, err := c.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	row := make(Row, len(c.columns))
+	for i, column := range c.columns {
+		if i < len(record) {
+			row[column] = record[i]
+		}
+	}
+	return row, nil
+}
+
+func (c *csvReader) Close() error {
+	if c.gzip != nil {
+		return c.gzip.Close()
+	}
+	return nil
+}
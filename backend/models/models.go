@@ -21,6 +21,7 @@ type RDSInstance struct {
 	EngineVersion    string `json:"engineVersion"`
 	Status           string `json:"status"`
 	AllocatedStorage int32  `json:"allocatedStorage"`
+	StorageType      string `json:"storageType"`
 }
 
 // EBSVolume represents an EBS volume
@@ -46,12 +47,39 @@ type CloudWatchLogGroup struct {
 	MetricFilterCount int32     `json:"metricFilterCount"`
 }
 
+// PublicIPv4Address represents a billed public IPv4 address, either an Elastic IP
+// or a public IP auto-assigned to an instance or network interface
+type PublicIPv4Address struct {
+	PublicIP           string  `json:"publicIp"`
+	Kind               string  `json:"kind"`
+	AllocationID       string  `json:"allocationId"`
+	Name               string  `json:"name"`
+	InstanceID         string  `json:"instanceId"`
+	NetworkInterfaceID string  `json:"networkInterfaceId"`
+	Associated         bool    `json:"associated"`
+	AccountID          string  `json:"accountId"`
+	Region             string  `json:"region"`
+	MonthlyCost        float64 `json:"monthlyCost"`
+}
+
+// PublicIPv4Charges represents the monthly public IPv4 charges of one account and region
+type PublicIPv4Charges struct {
+	AccountID               string  `json:"accountId"`
+	Region                  string  `json:"region"`
+	AddressCount            int     `json:"addressCount"`
+	ElasticIPCount          int     `json:"elasticIpCount"`
+	UnassociatedCount       int     `json:"unassociatedCount"`
+	UnassociatedMonthlyCost float64 `json:"unassociatedMonthlyCost"`
+	MonthlyCost             float64 `json:"monthlyCost"`
+}
+
 // CostByService represents the cost data for a specific service
 type CostByService struct {
-	Service string `json:"service"`
-	Amount  string `json:"amount"`
-	Unit    string `json:"unit"`
-	Date    string `json:"date"`
+	Service  string `json:"service"`
+	Amount   string `json:"amount"`
+	Unit     string `json:"unit"`
+	Date     string `json:"date"`
+	Provider string `json:"provider,omitempty"`
 }
 
 // CostData represents the aggregated cost data
@@ -67,5 +95,133 @@ type ResourcesSummary struct {
 	RDSInstances        []RDSInstance        `json:"rdsInstances"`
 	EBSVolumes          []EBSVolume          `json:"ebsVolumes"`
 	CloudWatchLogGroups []CloudWatchLogGroup `json:"cloudWatchLogGroups"`
+	PublicIPv4Addresses []PublicIPv4Address  `json:"publicIpv4Addresses"`
+	PublicIPv4Charges   []PublicIPv4Charges  `json:"publicIpv4Charges"`
 	CostData            *CostData            `json:"costData"`
 }
+
+// ResourceInventory lists the EC2 instances, RDS instances, EBS volumes and log groups
+type ResourceInventory struct {
+	EC2Instances        []EC2Instance        `json:"ec2"`
+	RDSInstances        []RDSInstance        `json:"rds"`
+	EBSVolumes          []EBSVolume          `json:"ebs"`
+	CloudWatchLogGroups []CloudWatchLogGroup `json:"cloudwatch_logs"`
+}
+
+// PublicIPv4Report lists billed public IPv4 addresses and their charges per account and region
+type PublicIPv4Report struct {
+	Addresses []PublicIPv4Address `json:"addresses"`
+	Charges   []PublicIPv4Charges `json:"charges"`
+}
+
+// StoppedResourceCost represents a stopped instance that still accrues storage or IP charges
+type StoppedResourceCost struct {
+	ID                   string   `json:"id"`
+	Name                 string   `json:"name"`
+	ResourceType         string   `json:"resourceType"`
+	State                string   `json:"state"`
+	VolumeIDs            []string `json:"volumeIds"`
+	StorageGB            int32    `json:"storageGb"`
+	StorageMonthlyCost   float64  `json:"storageMonthlyCost"`
+	ElasticIPs           []string `json:"elasticIps"`
+	ElasticIPMonthlyCost float64  `json:"elasticIpMonthlyCost"`
+	MonthlyCost          float64  `json:"monthlyCost"`
+}
+
+// StoppedCostReport totals the charges of stopped instances that are still costing money
+type StoppedCostReport struct {
+	Resources                 []StoppedResourceCost `json:"resources"`
+	TotalStorageMonthlyCost   float64               `json:"totalStorageMonthlyCost"`
+	TotalElasticIPMonthlyCost float64               `json:"totalElasticIpMonthlyCost"`
+	TotalMonthlyCost          float64               `json:"totalMonthlyCost"`
+	GeneratedAt               time.Time             `json:"generatedAt"`
+}
+
+// EBSSnapshot represents an EBS snapshot owned by the account
+type EBSSnapshot struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	VolumeID     string    `json:"volumeId"`
+	VolumeSize   int32     `json:"volumeSize"`
+	StorageTier  string    `json:"storageTier"`
+	State        string    `json:"state"`
+	StartTime    time.Time `json:"startTime"`
+	AgeDays      int       `json:"ageDays"`
+	SourceExists bool      `json:"sourceExists"`
+	SourceKnown  bool      `json:"sourceKnown"`
+	ImageIDs     []string  `json:"imageIds,omitempty"`
+	MonthlyCost  float64   `json:"monthlyCost"`
+}
+
+// RDSSnapshot represents a manual or automated RDS DB snapshot
+type RDSSnapshot struct {
+	ID               string    `json:"id"`
+	DBInstanceID     string    `json:"dbInstanceId"`
+	SnapshotType     string    `json:"snapshotType"`
+	Engine           string    `json:"engine"`
+	AllocatedStorage int32     `json:"allocatedStorage"`
+	Status           string    `json:"status"`
+	CreationTime     time.Time `json:"creationTime"`
+	AgeDays          int       `json:"ageDays"`
+	SourceExists     bool      `json:"sourceExists"`
+	MonthlyCost      float64   `json:"monthlyCost"`
+}
+
+// SnapshotCleanupCandidate represents a snapshot that is orphaned or older than the retention policy
+type SnapshotCleanupCandidate struct {
+	ID           string   `json:"id"`
+	ResourceType string   `json:"resourceType"`
+	SourceID     string   `json:"sourceId"`
+	AgeDays      int      `json:"ageDays"`
+	Reasons      []string `json:"reasons"`
+	MonthlyCost  float64  `json:"monthlyCost"`
+}
+
+// SnapshotReport lists snapshot cleanup candidates and their combined monthly cost
+type SnapshotReport struct {
+	MaxAgeDays       int                        `json:"maxAgeDays"`
+	Candidates       []SnapshotCleanupCandidate `json:"candidates"`
+	OrphanedCount    int                        `json:"orphanedCount"`
+	ExpiredCount     int                        `json:"expiredCount"`
+	TotalMonthlyCost float64                    `json:"totalMonthlyCost"`
+	GeneratedAt      time.Time                  `json:"generatedAt"`
+}
+
+// DataTransferUsage represents the cost of a data transfer usage type over the report period
+type DataTransferUsage struct {
+	Category  string  `json:"category"`
+	Service   string  `json:"service"`
+	UsageType string  `json:"usageType"`
+	UsageGB   float64 `json:"usageGb"`
+	Amount    float64 `json:"amount"`
+}
+
+// DataTransferTrendPoint represents data transfer cost by category for one period of the trend
+type DataTransferTrendPoint struct {
+	Date       string             `json:"date"`
+	ByCategory map[string]float64 `json:"byCategory"`
+	Total      float64            `json:"total"`
+}
+
+// DataTransferReport breaks data transfer cost down by category, usage type and period
+type DataTransferReport struct {
+	TimeStart   string                   `json:"timeStart"`
+	TimeEnd     string                   `json:"timeEnd"`
+	Granularity string                   `json:"granularity"`
+	Unit        string                   `json:"unit"`
+	ByCategory  map[string]float64       `json:"byCategory"`
+	Usage       []DataTransferUsage      `json:"usage"`
+	Trend       []DataTransferTrendPoint `json:"trend"`
+	Total       float64                  `json:"total"`
+}
+
+// Recommendation is a cost saving action found by the stopped-cost and snapshot reports
+type Recommendation struct {
+	ResourceID     string   `json:"resourceId"`
+	Name           string   `json:"name"`
+	ResourceType   string   `json:"resourceType"`
+	Action         string   `json:"action"`
+	Reasons        []string `json:"reasons"`
+	MonthlySavings float64  `json:"monthlySavings"`
+}